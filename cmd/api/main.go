@@ -2,10 +2,12 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
@@ -17,6 +19,10 @@ import (
 
 const (
 	serverPort = "8080"
+
+	// defaultGracePeriod drains the worker pool when the configuration sets
+	// no grace period, so queued jobs are not dead-lettered straight away
+	defaultGracePeriod = 30 * time.Second
 )
 
 func main() {
@@ -35,13 +41,43 @@ func main() {
 	)
 
 	// Initialize handlers
-	subscriptionHandler := handlers.NewSubscriptionHandler(cfg)
-	holidaysHandler := handlers.NewHolidaysFetchHandler(holidays.NewService(holidays.NewClient()))
+	subscriptionHandler, err := handlers.NewSubscriptionHandler(cfg)
+	if err != nil {
+		log.Fatalf("Failed to create subscription handler: %v", err)
+	}
+	holidaysClients, err := newHolidaysClient(cfg)
+	if err != nil {
+		log.Fatalf("Failed to create holidays client: %v", err)
+	}
+	holidaysConfig := cfg.GetHolidaysConfig()
+	holidaysService := holidays.NewService(holidaysClients.client, holidays.WithConcurrency(holidaysConfig.Concurrency))
+	holidaysHandler := handlers.NewHolidaysFetchHandler(holidaysService, handlers.WithMaxCountries(holidaysConfig.MaxCountries))
+	weekends, err := weekendDays(holidaysConfig.Weekends)
+	if err != nil {
+		log.Fatalf("Invalid holidays weekends: %v", err)
+	}
+	calendar := holidays.NewCalendar(holidaysService, holidays.WithWeekends(weekends))
+	businessDaysHandler := handlers.NewBusinessDaysHandler(calendar)
+	shipmentETAHandler := handlers.NewShipmentETAHandler(calendar)
+	nextHolidaysHandler := handlers.NewNextHolidaysHandler(calendar)
+	holidayCheckHandler := handlers.NewHolidayCheckHandler(calendar)
+	longWeekendsHandler := handlers.NewLongWeekendsHandler(calendar)
+	statusOptions := []handlers.HolidaysStatusOption{handlers.WithProviderHealth(holidaysClients.failover)}
+	if holidaysClients.cache != nil {
+		statusOptions = append(statusOptions, handlers.WithCacheStats(holidaysClients.cache))
+	}
+	holidaysStatusHandler := handlers.NewHolidaysStatusHandler(statusOptions...)
 
 	// Setup router
 	mux := http.NewServeMux()
 	mux.Handle("/subscriptions", middlewareChain(subscriptionHandler))
 	mux.Handle("/public-holidays", middlewareChain(holidaysHandler))
+	mux.Handle("/public-holidays/next", middlewareChain(nextHolidaysHandler))
+	mux.Handle("/public-holidays/check", middlewareChain(holidayCheckHandler))
+	mux.Handle("/public-holidays/status", middlewareChain(holidaysStatusHandler))
+	mux.Handle("/business-days", middlewareChain(businessDaysHandler))
+	mux.Handle("/shipments/eta", middlewareChain(shipmentETAHandler))
+	mux.Handle("/long-weekends", middlewareChain(longWeekendsHandler))
 
 	// Create server
 	srv := &http.Server{
@@ -62,10 +98,165 @@ func main() {
 	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
 	<-stop
 
-	// Graceful shutdown
+	// Graceful shutdown: stop accepting HTTP requests first so no new jobs
+	// arrive, then drain the worker pool within the configured grace period
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 	if err := srv.Shutdown(ctx); err != nil {
 		log.Printf("Server shutdown error: %v", err)
 	}
+
+	grace := time.Duration(cfg.GetWorkerConfig().Shutdown.GracePeriod) * time.Second
+	if grace <= 0 {
+		grace = defaultGracePeriod
+	}
+	drainCtx, drainCancel := context.WithTimeout(context.Background(), grace)
+	defer drainCancel()
+	subscriptionHandler.Shutdown(drainCtx)
+	log.Println("Shutdown complete")
+}
+
+// holidaysClients is the assembled holiday client and the decorators whose
+// state is reported on the status endpoint
+type holidaysClients struct {
+	client   holidays.Client
+	failover *holidays.FailoverClient
+	// cache is nil when caching is disabled
+	cache *holidays.CachingClient
+}
+
+// newHolidaysClient builds the upstream holiday client with its decorators
+func newHolidaysClient(cfg *config.Config) (holidaysClients, error) {
+	holidaysConfig := cfg.GetHolidaysConfig()
+
+	providers, err := newHolidayProviders(cfg)
+	if err != nil {
+		return holidaysClients{}, err
+	}
+
+	breakerConfig := holidaysConfig.CircuitBreaker
+	failover := holidays.NewFailoverClient(holidays.BreakerOptions{
+		FailureThreshold: breakerConfig.FailureThreshold,
+		Cooldown:         time.Duration(breakerConfig.Cooldown) * time.Second,
+	}, providers...)
+	var client holidays.Client = failover
+	if holidaysConfig.UpstreamConcurrency > 0 {
+		client = holidays.NewLimitedClient(client, holidaysConfig.UpstreamConcurrency)
+	}
+	if storeConfig := holidaysConfig.Store; storeConfig.Dir != "" {
+		persistent, err := holidays.NewPersistentClient(client, storeConfig.Dir, storeConfig.Offline)
+		if err != nil {
+			return holidaysClients{}, err
+		}
+		client = persistent
+	}
+
+	// Coalesce concurrent cache misses into a single upstream call
+	client = holidays.NewCoalescingClient(client)
+	clients := holidaysClients{client: client, failover: failover}
+	if cacheConfig := holidaysConfig.Cache; cacheConfig.TTL > 0 {
+		clients.cache = holidays.NewCachingClient(client, holidays.CacheOptions{
+			TTL:        time.Duration(cacheConfig.TTL) * time.Second,
+			ErrorTTL:   time.Duration(cacheConfig.ErrorTTL) * time.Second,
+			MaxEntries: cacheConfig.MaxEntries,
+		})
+		clients.client = clients.cache
+	}
+	return clients, nil
+}
+
+// newHolidayProviders creates the configured holiday providers, defaulting
+// to the Nager.Date API alone
+func newHolidayProviders(cfg *config.Config) ([]holidays.Provider, error) {
+	providerConfigs := cfg.GetHolidaysConfig().Providers
+	if len(providerConfigs) == 0 {
+		providerConfigs = []config.HolidaysProvider{{Name: "nager", Type: "nager"}}
+	}
+
+	providers := make([]holidays.Provider, 0, len(providerConfigs))
+	for _, providerConfig := range providerConfigs {
+		var client holidays.Client
+		switch providerConfig.Type {
+		case "nager":
+			// Upstream settings are re-read on every lookup so they can be hot-reloaded
+			httpClient, err := holidays.NewConfiguredClient(func() holidays.ClientSettings {
+				return upstreamSettings(cfg.GetHolidaysConfig())
+			})
+			if err != nil {
+				return nil, err
+			}
+			client = httpClient
+		case "static":
+			staticClient, err := holidays.LoadStaticClient(providerConfig.Path)
+			if err != nil {
+				return nil, err
+			}
+			client = staticClient
+		case "embedded":
+			embeddedClient, err := holidays.NewEmbeddedClient()
+			if err != nil {
+				return nil, err
+			}
+			client = embeddedClient
+		case "rules":
+			client = holidays.NewRuleClient(holidays.DefaultRules)
+		case "feed":
+			if providerConfig.URL == "" {
+				return nil, fmt.Errorf("holiday provider %q has no url", providerConfig.Name)
+			}
+			client = holidays.NewFeedClient(providerConfig.URL)
+		default:
+			return nil, fmt.Errorf("holiday provider %q has unknown type %q", providerConfig.Name, providerConfig.Type)
+		}
+
+		name := providerConfig.Name
+		if name == "" {
+			name = providerConfig.Type
+		}
+		providers = append(providers, holidays.Provider{Name: name, Client: client})
+	}
+	return providers, nil
+}
+
+// weekendDays parses the configured weekend day names of each country
+func weekendDays(weekends map[string][]string) (map[string][]time.Weekday, error) {
+	days := make(map[string][]time.Weekday, len(weekends))
+	for country, names := range weekends {
+		for _, name := range names {
+			day, ok := weekdays[strings.ToLower(name)]
+			if !ok {
+				return nil, fmt.Errorf("unknown weekday %q for %s", name, country)
+			}
+			days[country] = append(days[country], day)
+		}
+	}
+	return days, nil
+}
+
+var weekdays = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
+
+// upstreamSettings converts configuration into holiday client settings
+func upstreamSettings(holidaysConfig config.HolidaysConfig) holidays.ClientSettings {
+	upstreamConfig, retryConfig := holidaysConfig.Upstream, holidaysConfig.Retry
+	return holidays.ClientSettings{
+		BaseURL:            upstreamConfig.BaseURL,
+		Timeout:            time.Duration(upstreamConfig.Timeout) * time.Second,
+		UserAgent:          upstreamConfig.UserAgent,
+		ProxyURL:           upstreamConfig.Proxy,
+		CAFile:             upstreamConfig.CAFile,
+		InsecureSkipVerify: upstreamConfig.InsecureSkipVerify,
+		Retry: holidays.RetryPolicy{
+			MaxAttempts:    retryConfig.MaxAttempts,
+			InitialBackoff: time.Duration(retryConfig.InitialBackoff) * time.Millisecond,
+			MaxBackoff:     time.Duration(retryConfig.MaxBackoff) * time.Millisecond,
+		},
+	}
 }
@@ -0,0 +1,139 @@
+// Command holidaysgen regenerates the holiday dataset embedded in the
+// holidays package, either from the upstream API or from a JSON dump.
+//
+//	go run ./cmd/holidaysgen -years 2025-2028 -out internal/holidays/data/holidays.json
+//	go run ./cmd/holidaysgen -dump export.json -out internal/holidays/data/holidays.json
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"kln-test/internal/holidays"
+)
+
+// defaultCountries are the major shipping countries bundled by default
+const defaultCountries = "US,CA,GB,DE,FR,NL,IT"
+
+func main() {
+	thisYear := time.Now().Year()
+
+	var (
+		countries = flag.String("countries", defaultCountries, "comma-separated country codes to include")
+		years     = flag.String("years", fmt.Sprintf("%d-%d", thisYear-1, thisYear+2), "year or inclusive range of years, e.g. 2025-2028")
+		baseURL   = flag.String("base-url", holidays.DefaultBaseURL, "upstream API to fetch from")
+		dump      = flag.String("dump", "", "read holidays from this JSON array instead of the API")
+		out       = flag.String("out", "internal/holidays/data/holidays.json", "file to write the dataset to")
+	)
+	flag.Parse()
+
+	from, to, err := parseYears(*years)
+	if err != nil {
+		log.Fatal(err)
+	}
+	codes := strings.Split(strings.ToUpper(*countries), ",")
+
+	var dataset []holidays.Holiday
+	if *dump != "" {
+		dataset, err = readDump(*dump, codes, from, to)
+	} else {
+		dataset, err = fetch(*baseURL, codes, from, to)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	sort.SliceStable(dataset, func(i, j int) bool {
+		if dataset[i].CountryCode != dataset[j].CountryCode {
+			return dataset[i].CountryCode < dataset[j].CountryCode
+		}
+		return dataset[i].Date < dataset[j].Date
+	})
+
+	data, err := json.MarshalIndent(dataset, "", "  ")
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*out, append(data, '\n'), 0o644); err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("Wrote %d holidays for %d countries, %d-%d, to %s", len(dataset), len(codes), from, to, *out)
+}
+
+// parseYears reads a single year or an inclusive "from-to" range
+func parseYears(value string) (int, int, error) {
+	fromText, toText, isRange := strings.Cut(value, "-")
+	if !isRange {
+		toText = fromText
+	}
+	from, err := strconv.Atoi(fromText)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid years %q", value)
+	}
+	to, err := strconv.Atoi(toText)
+	if err != nil || to < from {
+		return 0, 0, fmt.Errorf("invalid years %q", value)
+	}
+	return from, to, nil
+}
+
+// fetch downloads every country and year from the upstream API
+func fetch(baseURL string, codes []string, from, to int) ([]holidays.Holiday, error) {
+	client, err := holidays.NewConfiguredClient(func() holidays.ClientSettings {
+		return holidays.ClientSettings{BaseURL: baseURL, UserAgent: "kln-test-holidaysgen"}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var dataset []holidays.Holiday
+	for _, code := range codes {
+		for year := from; year <= to; year++ {
+			fetched, err := client.GetHolidays(context.Background(), year, code)
+			if err != nil {
+				return nil, fmt.Errorf("failed to fetch %s %d: %w", code, year, err)
+			}
+			dataset = append(dataset, fetched...)
+		}
+	}
+	return dataset, nil
+}
+
+// readDump loads a JSON array of holidays and keeps the requested countries
+// and years
+func readDump(path string, codes []string, from, to int) ([]holidays.Holiday, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var all []holidays.Holiday
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", path, err)
+	}
+
+	wanted := make(map[string]bool, len(codes))
+	for _, code := range codes {
+		wanted[code] = true
+	}
+
+	var dataset []holidays.Holiday
+	for _, holiday := range all {
+		if len(holiday.Date) < 4 || !wanted[strings.ToUpper(holiday.CountryCode)] {
+			continue
+		}
+		year, err := strconv.Atoi(holiday.Date[:4])
+		if err != nil || year < from || year > to {
+			continue
+		}
+		dataset = append(dataset, holiday)
+	}
+	return dataset, nil
+}
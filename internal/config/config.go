@@ -8,16 +8,21 @@ import (
 
 // Config holds all configuration settings
 type Config struct {
-	mu     sync.RWMutex
-	path   string
-	Worker WorkerConfig `json:"worker"`
-	Auth   AuthConfig   `json:"auth"`
+	mu       sync.RWMutex
+	path     string
+	Worker   WorkerConfig   `json:"worker"`
+	Auth     AuthConfig     `json:"auth"`
+	Holidays HolidaysConfig `json:"holidays"`
 }
 
 type WorkerConfig struct {
-	PoolSize  int               `json:"poolSize"`
-	QueueSize int               `json:"queueSize"`
-	Retry     WorkerRetryConfig `json:"retry"`
+	PoolSize  int                  `json:"poolSize"`
+	QueueSize int                  `json:"queueSize"`
+	Retry     WorkerRetryConfig    `json:"retry"`
+	Shutdown  WorkerShutdownConfig `json:"shutdown"`
+	Dedup     WorkerDedupConfig    `json:"dedup"`
+	Batch     WorkerBatchConfig    `json:"batch"`
+	Store     WorkerStoreConfig    `json:"store"`
 }
 
 type WorkerRetryConfig struct {
@@ -26,11 +31,117 @@ type WorkerRetryConfig struct {
 	MaxTimeout     int `json:"maxTimeout"`
 }
 
+// WorkerShutdownConfig controls how the pool is drained on shutdown.
+// GracePeriod is in seconds and defaults to 30 when unset; jobs left over
+// after it expires are appended to DeadLetterFile (or only logged when it
+// is empty).
+type WorkerShutdownConfig struct {
+	GracePeriod    int    `json:"gracePeriod"`
+	DeadLetterFile string `json:"deadLetterFile"`
+}
+
+// WorkerDedupConfig controls rejection of jobs whose ID is already queued,
+// running, or completed within the last Window seconds.
+type WorkerDedupConfig struct {
+	Enabled bool `json:"enabled"`
+	Window  int  `json:"window"`
+}
+
+// WorkerBatchConfig controls accumulation of jobs sharing a batch key.
+// Batching is disabled unless MaxSize is greater than one; MaxWait is in
+// milliseconds.
+type WorkerBatchConfig struct {
+	MaxSize int `json:"maxSize"`
+	MaxWait int `json:"maxWait"`
+}
+
+// WorkerStoreConfig configures a job store shared by several instances.
+// The store is only used when Dir is set; LeaseTTL and PollInterval are in
+// seconds.
+type WorkerStoreConfig struct {
+	Dir          string `json:"dir"`
+	LeaseTTL     int    `json:"leaseTtl"`
+	PollInterval int    `json:"pollInterval"`
+}
+
 type AuthConfig struct {
 	Username string `json:"username"`
 	Password string `json:"password"`
 }
 
+// HolidaysConfig configures holiday lookups. MaxCountries caps the
+// countries in one request, Concurrency caps the parallel fetches for one
+// request, and UpstreamConcurrency caps upstream calls across all requests.
+// Zero disables a limit.
+type HolidaysConfig struct {
+	MaxCountries        int                    `json:"maxCountries"`
+	Concurrency         int                    `json:"concurrency"`
+	UpstreamConcurrency int                    `json:"upstreamConcurrency"`
+	Upstream            HolidaysUpstreamConfig `json:"upstream"`
+	Retry               HolidaysRetryConfig    `json:"retry"`
+	Cache               HolidaysCacheConfig    `json:"cache"`
+	Store               HolidaysStoreConfig    `json:"store"`
+	Providers           []HolidaysProvider     `json:"providers"`
+	CircuitBreaker      CircuitBreakerConfig   `json:"circuitBreaker"`
+	// Weekends lists the weekend days of countries by English day name,
+	// e.g. "SA": ["Friday", "Saturday"]; others use Saturday and Sunday
+	Weekends map[string][]string `json:"weekends"`
+}
+
+// HolidaysUpstreamConfig configures how the holiday API is reached. Timeout
+// is in seconds; empty values fall back to the public Nager.Date defaults.
+// Changes are picked up without a restart.
+type HolidaysUpstreamConfig struct {
+	BaseURL            string `json:"baseUrl"`
+	Timeout            int    `json:"timeout"`
+	UserAgent          string `json:"userAgent"`
+	Proxy              string `json:"proxy"`
+	CAFile             string `json:"caFile"`
+	InsecureSkipVerify bool   `json:"insecureSkipVerify"`
+}
+
+// HolidaysRetryConfig controls retries of transient upstream failures.
+// Backoffs are in milliseconds; MaxAttempts of zero keeps the default policy.
+type HolidaysRetryConfig struct {
+	MaxAttempts    int `json:"maxAttempts"`
+	InitialBackoff int `json:"initialBackoff"`
+	MaxBackoff     int `json:"maxBackoff"`
+}
+
+// HolidaysCacheConfig configures the in-memory holiday cache. TTL and
+// ErrorTTL are in seconds; a zero TTL disables the cache.
+type HolidaysCacheConfig struct {
+	TTL        int `json:"ttl"`
+	ErrorTTL   int `json:"errorTtl"`
+	MaxEntries int `json:"maxEntries"`
+}
+
+// HolidaysStoreConfig configures on-disk persistence of fetched holidays.
+// Persistence is disabled when Dir is empty; Offline serves only saved data.
+type HolidaysStoreConfig struct {
+	Dir     string `json:"dir"`
+	Offline bool   `json:"offline"`
+}
+
+// HolidaysProvider is one holiday data source, tried in list order. Type is
+// "nager" (the upstream settings above), "static" (a JSON file at Path),
+// "embedded" (the dataset bundled into the binary), "rules" (holidays
+// computed from built-in rules) or "feed" (a URL with {year} and {country}
+// placeholders).
+type HolidaysProvider struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+	URL  string `json:"url,omitempty"`
+	Path string `json:"path,omitempty"`
+}
+
+// CircuitBreakerConfig controls when a failing provider is skipped.
+// Cooldown is in seconds; a zero FailureThreshold disables the breaker.
+type CircuitBreakerConfig struct {
+	FailureThreshold int `json:"failureThreshold"`
+	Cooldown         int `json:"cooldown"`
+}
+
 // Load reads the configuration file and returns a new Config instance
 func Load(path string) (*Config, error) {
 	cfg := &Config{path: path}
@@ -59,6 +170,7 @@ func (c *Config) Reload() error {
 	// Copy the loaded values to the current config
 	c.Worker = temp.Worker
 	c.Auth = temp.Auth
+	c.Holidays = temp.Holidays
 
 	return nil
 }
@@ -74,3 +186,9 @@ func (c *Config) GetWorkerConfig() WorkerConfig {
 	defer c.mu.RUnlock()
 	return c.Worker
 }
+
+func (c *Config) GetHolidaysConfig() HolidaysConfig {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.Holidays
+}
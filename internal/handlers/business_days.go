@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"kln-test/internal/holidays"
+	"kln-test/internal/render"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// maxBusinessDaysSpan bounds how far a single request may walk the calendar
+const maxBusinessDaysSpan = 10 * 366
+
+// BusinessDaysRequest represents the query parameters for the business days endpoint
+type BusinessDaysRequest struct {
+	Country   string `validate:"required,len=2"`
+	Operation string `validate:"required,oneof=add subtract count"`
+	Days      int    `validate:"min=0,max=3660"`
+}
+
+// BusinessDaysResponse is the answer to a business days request. For add
+// and subtract, To is the computed date; for count, Days is the count.
+type BusinessDaysResponse struct {
+	Country   string   `json:"country"`
+	Operation string   `json:"operation"`
+	From      string   `json:"from"`
+	To        string   `json:"to"`
+	Days      int      `json:"days"`
+	Weekend   []string `json:"weekend"`
+}
+
+// BusinessDaysHandler handles business day calculations
+type BusinessDaysHandler struct {
+	validator *validator.Validate
+	calendar  *holidays.Calendar
+}
+
+// NewBusinessDaysHandler creates a new business days handler
+func NewBusinessDaysHandler(calendar *holidays.Calendar) *BusinessDaysHandler {
+	return &BusinessDaysHandler{
+		validator: validator.New(),
+		calendar:  calendar,
+	}
+}
+
+// ServeHTTP handles HTTP requests for business day calculations
+func (h *BusinessDaysHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	from, err := time.Parse(holidays.DateLayout, query.Get("from"))
+	if err != nil {
+		http.Error(w, "Invalid from parameter, expected YYYY-MM-DD", http.StatusBadRequest)
+		return
+	}
+
+	req := BusinessDaysRequest{
+		Country:   strings.ToUpper(query.Get("country")),
+		Operation: query.Get("operation"),
+	}
+
+	var to time.Time
+	if req.Operation == "count" {
+		to, err = time.Parse(holidays.DateLayout, query.Get("to"))
+		if err != nil {
+			http.Error(w, "Invalid to parameter, expected YYYY-MM-DD", http.StatusBadRequest)
+			return
+		}
+		if span := to.Sub(from).Hours() / 24; span > maxBusinessDaysSpan || span < -maxBusinessDaysSpan {
+			http.Error(w, "Date range is too long", http.StatusBadRequest)
+			return
+		}
+	} else {
+		req.Days, err = strconv.Atoi(query.Get("days"))
+		if err != nil {
+			http.Error(w, "Invalid days parameter", http.StatusBadRequest)
+			return
+		}
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		writeValidationError(w, err)
+		return
+	}
+
+	filter, err := parseFilter(query, []string{req.Country})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	calendar := h.calendar.Filtered(filter)
+
+	response := BusinessDaysResponse{
+		Country:   req.Country,
+		Operation: req.Operation,
+		From:      from.Format(holidays.DateLayout),
+		Days:      req.Days,
+	}
+	for _, day := range h.calendar.Weekend(req.Country) {
+		response.Weekend = append(response.Weekend, day.String())
+	}
+
+	switch req.Operation {
+	case "count":
+		response.Days, err = calendar.CountBusinessDays(r.Context(), req.Country, from, to)
+	case "subtract":
+		to, err = calendar.AddBusinessDays(r.Context(), req.Country, from, -req.Days)
+	default:
+		to, err = calendar.AddBusinessDays(r.Context(), req.Country, from, req.Days)
+	}
+	if err != nil {
+		writeCalendarError(w, err)
+		return
+	}
+	response.To = to.Format(holidays.DateLayout)
+
+	render.Write(w, r, http.StatusOK, response)
+}
+
+// writeCalendarError reports a failed calendar lookup. A country no
+// provider knows is the client's mistake; anything else is an upstream
+// failure.
+func writeCalendarError(w http.ResponseWriter, err error) {
+	status := http.StatusBadGateway
+	switch {
+	case errors.Is(err, holidays.ErrNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, holidays.ErrUnknownSubdivision):
+		status = http.StatusUnprocessableEntity
+	case errors.Is(err, holidays.ErrNoBusinessDays):
+		status = http.StatusInternalServerError
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{
+		"error": err.Error(),
+	})
+}
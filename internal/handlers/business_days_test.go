@@ -0,0 +1,183 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"kln-test/internal/holidays"
+)
+
+func TestBusinessDaysHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		url        string
+		wantStatus int
+		wantTo     string
+		wantDays   int
+	}{
+		{
+			name:       "add",
+			url:        "/business-days?country=DE&operation=add&from=2025-12-23&days=5",
+			wantStatus: http.StatusOK,
+			wantTo:     "2026-01-02",
+			wantDays:   5,
+		},
+		{
+			name:       "subtract",
+			url:        "/business-days?country=de&operation=subtract&from=2026-01-02&days=5",
+			wantStatus: http.StatusOK,
+			wantTo:     "2025-12-23",
+			wantDays:   5,
+		},
+		{
+			name:       "count",
+			url:        "/business-days?country=DE&operation=count&from=2025-12-23&to=2026-01-02",
+			wantStatus: http.StatusOK,
+			wantTo:     "2026-01-02",
+			wantDays:   5,
+		},
+		{
+			name:       "subdivision holiday",
+			url:        "/business-days?country=DE&operation=add&from=2026-01-05&days=1&subdivision=DE-BY",
+			wantStatus: http.StatusOK,
+			wantTo:     "2026-01-07",
+			wantDays:   1,
+		},
+		{
+			name:       "subdivision of another country",
+			url:        "/business-days?country=DE&operation=add&from=2026-01-05&days=1&subdivision=CA-ON",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "unknown subdivision",
+			url:        "/business-days?country=DE&operation=add&from=2026-01-05&days=1&subdivision=DE-XX",
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "excluded type",
+			url:        "/business-days?country=DE&operation=add&from=2025-12-23&days=5&excludeType=Public",
+			wantStatus: http.StatusOK,
+			wantTo:     "2025-12-30",
+			wantDays:   5,
+		},
+		{
+			name:       "unknown type",
+			url:        "/business-days?country=DE&operation=add&from=2025-12-23&days=5&type=Religious",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid from",
+			url:        "/business-days?country=DE&operation=add&from=23-12-2025&days=5",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "missing days",
+			url:        "/business-days?country=DE&operation=add&from=2025-12-23",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "unknown operation",
+			url:        "/business-days?country=DE&operation=multiply&from=2025-12-23&days=5",
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "negative days",
+			url:        "/business-days?country=DE&operation=add&from=2025-12-23&days=-5",
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "range too long",
+			url:        "/business-days?country=DE&operation=count&from=2000-01-01&to=2025-01-01",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "unknown country",
+			url:        "/business-days?country=ZZ&operation=add&from=2025-12-23&days=5",
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	calendar := holidays.NewCalendar(holidays.NewService(holidays.NewRuleClient(holidays.DefaultRules)))
+	handler := NewBusinessDaysHandler(calendar)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("Expected status code %d, got %d", tt.wantStatus, rec.Code)
+			}
+
+			if rec.Code == http.StatusOK {
+				var response BusinessDaysResponse
+				if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				if response.To != tt.wantTo || response.Days != tt.wantDays {
+					t.Errorf("Expected to %s and %d days, got %s and %d", tt.wantTo, tt.wantDays, response.To, response.Days)
+				}
+			}
+		})
+	}
+}
+
+func TestWriteCalendarError(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{
+			name:       "unknown country",
+			err:        fmt.Errorf("failed to get holidays for ZZ 2025: %w", holidays.ErrNotFound),
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "unknown country on every provider",
+			err:        fmt.Errorf("all providers failed: %w", errors.Join(holidays.ErrNotFound, holidays.ErrNotFound)),
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "unknown subdivision",
+			err:        holidays.ErrUnknownSubdivision,
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "outage",
+			err:        &holidays.StatusError{StatusCode: http.StatusServiceUnavailable},
+			wantStatus: http.StatusBadGateway,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+
+			writeCalendarError(rec, tt.err)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("Expected status code %d, got %d", tt.wantStatus, rec.Code)
+			}
+		})
+	}
+}
+
+func TestBusinessDaysHandlerUpstreamFailure(t *testing.T) {
+	failover := holidays.NewFailoverClient(holidays.BreakerOptions{}, holidays.Provider{Name: "down", Client: failingClient{}})
+	handler := NewBusinessDaysHandler(holidays.NewCalendar(holidays.NewService(failover)))
+
+	req := httptest.NewRequest(http.MethodGet, "/business-days?country=DE&operation=add&from=2025-12-23&days=5", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadGateway {
+		t.Errorf("Expected status code %d, got %d", http.StatusBadGateway, rec.Code)
+	}
+}
@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"kln-test/internal/holidays"
+)
+
+// parseFilter reads the subdivision, type and excludeType parameters of a
+// request. Each subdivision must belong to one of countries.
+func parseFilter(query url.Values, countries []string) (holidays.Filter, error) {
+	var filter holidays.Filter
+	for _, value := range query["subdivision"] {
+		subdivision, err := holidays.ParseSubdivision(value)
+		if err != nil {
+			return filter, err
+		}
+		if !containsFold(countries, subdivision[:2]) {
+			return filter, fmt.Errorf("subdivision %s is not in a requested country", subdivision)
+		}
+		filter.Subdivisions = append(filter.Subdivisions, subdivision)
+	}
+
+	var err error
+	if filter.IncludeTypes, err = parseHolidayTypes(query["type"]); err != nil {
+		return filter, err
+	}
+	if filter.ExcludeTypes, err = parseHolidayTypes(query["excludeType"]); err != nil {
+		return filter, err
+	}
+	return filter, nil
+}
+
+func parseHolidayTypes(values []string) ([]string, error) {
+	var types []string
+	for _, value := range values {
+		holidayType, err := holidays.ParseHolidayType(value)
+		if err != nil {
+			return nil, err
+		}
+		types = append(types, holidayType)
+	}
+	return types, nil
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// uniqueFold returns values without repeats, compared case-insensitively,
+// keeping the first spelling of each in order.
+func uniqueFold(values []string) []string {
+	var unique []string
+	for _, v := range values {
+		if !containsFold(unique, v) {
+			unique = append(unique, v)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,204 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"kln-test/internal/holidays"
+	"kln-test/internal/render"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// NextHolidaysRequest represents the query parameters for the next holidays endpoint
+type NextHolidaysRequest struct {
+	Country string `validate:"required,len=2"`
+	Count   int    `validate:"min=1,max=50"`
+}
+
+// NextHolidaysResponse lists the upcoming holidays of a country. Today is
+// the current date in Timezone.
+type NextHolidaysResponse struct {
+	Country  string             `json:"country"`
+	Timezone string             `json:"timezone"`
+	Today    string             `json:"today"`
+	Holidays []holidays.Holiday `json:"holidays"`
+}
+
+// HolidayCheckRequest represents the query parameters for the holiday check endpoint
+type HolidayCheckRequest struct {
+	Country string `validate:"required,len=2"`
+}
+
+// HolidayCheckResponse tells whether a date is a holiday in a country
+type HolidayCheckResponse struct {
+	Country   string             `json:"country"`
+	Timezone  string             `json:"timezone"`
+	Date      string             `json:"date"`
+	IsHoliday bool               `json:"isHoliday"`
+	Holidays  []holidays.Holiday `json:"holidays"`
+}
+
+// NextHolidaysHandler answers "what are the next holidays in a country"
+type NextHolidaysHandler struct {
+	validator *validator.Validate
+	calendar  *holidays.Calendar
+	now       func() time.Time
+}
+
+// NewNextHolidaysHandler creates a new next holidays handler
+func NewNextHolidaysHandler(calendar *holidays.Calendar) *NextHolidaysHandler {
+	return &NextHolidaysHandler{
+		validator: validator.New(),
+		calendar:  calendar,
+		now:       time.Now,
+	}
+}
+
+// ServeHTTP handles HTTP requests for upcoming holidays
+func (h *NextHolidaysHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	req := NextHolidaysRequest{
+		Country: strings.ToUpper(query.Get("country")),
+		Count:   1,
+	}
+	if value := query.Get("count"); value != "" {
+		count, err := strconv.Atoi(value)
+		if err != nil {
+			http.Error(w, "Invalid count parameter", http.StatusBadRequest)
+			return
+		}
+		req.Count = count
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		writeValidationError(w, err)
+		return
+	}
+
+	filter, err := parseFilter(query, []string{req.Country})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	location, ok := requestLocation(w, query, req.Country)
+	if !ok {
+		return
+	}
+	today := holidays.Today(h.now(), location)
+
+	next, err := h.calendar.Filtered(filter).NextHolidays(r.Context(), req.Country, today, req.Count)
+	if err != nil {
+		writeCalendarError(w, err)
+		return
+	}
+
+	render.Write(w, r, http.StatusOK, NextHolidaysResponse{
+		Country:  req.Country,
+		Timezone: location.String(),
+		Today:    today.Format(holidays.DateLayout),
+		Holidays: next,
+	})
+}
+
+// HolidayCheckHandler answers "is a date a holiday in a country"
+type HolidayCheckHandler struct {
+	validator *validator.Validate
+	calendar  *holidays.Calendar
+	now       func() time.Time
+}
+
+// NewHolidayCheckHandler creates a new holiday check handler
+func NewHolidayCheckHandler(calendar *holidays.Calendar) *HolidayCheckHandler {
+	return &HolidayCheckHandler{
+		validator: validator.New(),
+		calendar:  calendar,
+		now:       time.Now,
+	}
+}
+
+// ServeHTTP handles HTTP requests checking a single date. Without a date
+// parameter, today in the requested time zone is checked.
+func (h *HolidayCheckHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	req := HolidayCheckRequest{
+		Country: strings.ToUpper(query.Get("country")),
+	}
+	if err := h.validator.Struct(req); err != nil {
+		writeValidationError(w, err)
+		return
+	}
+
+	filter, err := parseFilter(query, []string{req.Country})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	location, ok := requestLocation(w, query, req.Country)
+	if !ok {
+		return
+	}
+	date := holidays.Today(h.now(), location)
+	if value := query.Get("date"); value != "" {
+		if date, err = time.Parse(holidays.DateLayout, value); err != nil {
+			http.Error(w, "Invalid date parameter, expected YYYY-MM-DD", http.StatusBadRequest)
+			return
+		}
+	}
+
+	found, err := h.calendar.Filtered(filter).HolidaysOn(r.Context(), req.Country, date)
+	if err != nil {
+		writeCalendarError(w, err)
+		return
+	}
+	if found == nil {
+		found = []holidays.Holiday{}
+	}
+
+	render.Write(w, r, http.StatusOK, HolidayCheckResponse{
+		Country:   req.Country,
+		Timezone:  location.String(),
+		Date:      date.Format(holidays.DateLayout),
+		IsHoliday: len(found) > 0,
+		Holidays:  found,
+	})
+}
+
+// requestLocation returns the time zone named by the tz parameter, or the
+// country's own time zone when it is absent
+func requestLocation(w http.ResponseWriter, query url.Values, country string) (*time.Location, bool) {
+	name := query.Get("tz")
+	if name == "" {
+		return holidays.CountryLocation(country), true
+	}
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		http.Error(w, "Invalid tz parameter, expected an IANA time zone such as Europe/Berlin", http.StatusBadRequest)
+		return nil, false
+	}
+	return location, true
+}
+
+// writeValidationError reports a request that failed validation
+func writeValidationError(w http.ResponseWriter, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusUnprocessableEntity)
+	json.NewEncoder(w).Encode(map[string]string{
+		"error":   "Validation failed",
+		"details": err.Error(),
+	})
+}
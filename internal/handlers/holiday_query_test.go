@@ -0,0 +1,167 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"kln-test/internal/holidays"
+)
+
+// christmasEve is late on Christmas Eve in UTC, already Christmas Day in Berlin
+var christmasEve = time.Date(2025, time.December, 24, 23, 30, 0, 0, time.UTC)
+
+func TestNextHolidaysHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		url        string
+		wantStatus int
+		wantToday  string
+		wantDates  []string
+	}{
+		{
+			name:       "country time zone",
+			url:        "/public-holidays/next?country=DE&count=3",
+			wantStatus: http.StatusOK,
+			wantToday:  "2025-12-25",
+			wantDates:  []string{"2025-12-25", "2025-12-26", "2026-01-01"},
+		},
+		{
+			name:       "caller time zone",
+			url:        "/public-holidays/next?country=US&tz=America/Los_Angeles",
+			wantStatus: http.StatusOK,
+			wantToday:  "2025-12-24",
+			wantDates:  []string{"2025-12-25"},
+		},
+		{
+			name:       "invalid time zone",
+			url:        "/public-holidays/next?country=DE&tz=Mars/Olympus",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid count",
+			url:        "/public-holidays/next?country=DE&count=many",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "count too large",
+			url:        "/public-holidays/next?country=DE&count=500",
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "missing country",
+			url:        "/public-holidays/next",
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+	}
+
+	calendar := holidays.NewCalendar(holidays.NewService(holidays.NewRuleClient(holidays.DefaultRules)))
+	handler := NewNextHolidaysHandler(calendar)
+	handler.now = func() time.Time { return christmasEve }
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("Expected status code %d, got %d", tt.wantStatus, rec.Code)
+			}
+
+			if rec.Code == http.StatusOK {
+				var response NextHolidaysResponse
+				if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				if response.Today != tt.wantToday {
+					t.Errorf("Expected today %s, got %s", tt.wantToday, response.Today)
+				}
+				var dates []string
+				for _, holiday := range response.Holidays {
+					dates = append(dates, holiday.Date)
+				}
+				if len(dates) != len(tt.wantDates) {
+					t.Fatalf("Expected holidays on %v, got %v", tt.wantDates, dates)
+				}
+				for i := range dates {
+					if dates[i] != tt.wantDates[i] {
+						t.Errorf("Expected holidays on %v, got %v", tt.wantDates, dates)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestHolidayCheckHandler(t *testing.T) {
+	tests := []struct {
+		name          string
+		url           string
+		wantStatus    int
+		wantDate      string
+		wantIsHoliday bool
+	}{
+		{
+			name:          "today in the country",
+			url:           "/public-holidays/check?country=DE",
+			wantStatus:    http.StatusOK,
+			wantDate:      "2025-12-25",
+			wantIsHoliday: true,
+		},
+		{
+			name:          "today for the caller",
+			url:           "/public-holidays/check?country=DE&tz=UTC",
+			wantStatus:    http.StatusOK,
+			wantDate:      "2025-12-24",
+			wantIsHoliday: false,
+		},
+		{
+			name:          "given date",
+			url:           "/public-holidays/check?country=fr&date=2025-07-14",
+			wantStatus:    http.StatusOK,
+			wantDate:      "2025-07-14",
+			wantIsHoliday: true,
+		},
+		{
+			name:       "invalid date",
+			url:        "/public-holidays/check?country=FR&date=14/07/2025",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid country",
+			url:        "/public-holidays/check?country=FRA",
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+	}
+
+	calendar := holidays.NewCalendar(holidays.NewService(holidays.NewRuleClient(holidays.DefaultRules)))
+	handler := NewHolidayCheckHandler(calendar)
+	handler.now = func() time.Time { return christmasEve }
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("Expected status code %d, got %d", tt.wantStatus, rec.Code)
+			}
+
+			if rec.Code == http.StatusOK {
+				var response HolidayCheckResponse
+				if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				if response.Date != tt.wantDate || response.IsHoliday != tt.wantIsHoliday {
+					t.Errorf("Expected %s holiday=%v, got %s holiday=%v", tt.wantDate, tt.wantIsHoliday, response.Date, response.IsHoliday)
+				}
+			}
+		})
+	}
+}
@@ -1,38 +1,72 @@
 package handlers
 
 import (
-	"encoding/json"
+	"fmt"
 	"net/http"
+	"net/url"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
 
 	"kln-test/internal/holidays"
+	"kln-test/internal/ical"
+	"kln-test/internal/render"
 
 	"github.com/go-playground/validator/v10"
 )
 
 // HolidaysRequest represents the query parameters for the holidays endpoint
 type HolidaysRequest struct {
-	Year      int      `validate:"required,min=1900,max=2100"`
+	Years     []int    `validate:"required,min=1,max=10,dive,min=1900,max=2100"`
 	Countries []string `validate:"required,min=1,dive,required,len=2"`
+	Mode      string   `validate:"omitempty,oneof=intersect union difference"`
 }
 
 // HolidaysFetchHandler handles public holiday requests
 type HolidaysFetchHandler struct {
-	validator *validator.Validate
-	service   holidays.Service
+	validator    *validator.Validate
+	service      holidays.Service
+	maxCountries int
+	now          func() time.Time
 }
 
+// HolidaysFetchOption customises a HolidaysFetchHandler
+type HolidaysFetchOption func(*HolidaysFetchHandler)
+
+// WithMaxCountries limits how many countries a single request may ask for.
+// Zero or less means no limit.
+func WithMaxCountries(n int) HolidaysFetchOption {
+	return func(h *HolidaysFetchHandler) {
+		h.maxCountries = n
+	}
+}
+
+// HolidaysResponse lists each country's holidays. Year is the first year
+// requested; Years lists them all when there are several, and From and To
+// are set for date range requests. When an aggregation mode is requested,
+// Dates also combines the countries into a single calendar.
 type HolidaysResponse struct {
 	Year    int                      `json:"year"`
+	Years   []int                    `json:"years,omitempty"`
+	From    string                   `json:"from,omitempty"`
+	To      string                   `json:"to,omitempty"`
+	Mode    string                   `json:"mode,omitempty"`
+	Dates   []holidays.DateEntry     `json:"dates,omitempty"`
 	Results []holidays.CountryResult `json:"results"`
 }
 
 // NewHolidaysFetchHandler creates a new holidays handler
-func NewHolidaysFetchHandler(service holidays.Service) *HolidaysFetchHandler {
-	return &HolidaysFetchHandler{
+func NewHolidaysFetchHandler(service holidays.Service, opts ...HolidaysFetchOption) *HolidaysFetchHandler {
+	h := &HolidaysFetchHandler{
 		validator: validator.New(),
 		service:   service,
+		now:       time.Now,
 	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
 }
 
 // ServeHTTP handles HTTP requests for public holidays
@@ -43,41 +77,203 @@ func (h *HolidaysFetchHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 	}
 
 	// Parse query parameters
-	yearStr := r.URL.Query().Get("year")
-	year, err := strconv.Atoi(yearStr)
-	if err != nil {
-		http.Error(w, "Invalid year parameter", http.StatusBadRequest)
+	query := r.URL.Query()
+	years, from, to, ok := parsePeriod(w, query)
+	if !ok {
 		return
 	}
+	ranged := !from.IsZero()
 
-	countries := r.URL.Query()["country"]
+	countries := uniqueFold(query["country"])
 	if len(countries) == 0 {
 		http.Error(w, "At least one country parameter is required", http.StatusBadRequest)
 		return
 	}
+	if h.maxCountries > 0 && len(countries) > h.maxCountries {
+		http.Error(w, fmt.Sprintf("Too many country parameters, at most %d are allowed", h.maxCountries), http.StatusBadRequest)
+		return
+	}
 
 	// Validate request
 	req := HolidaysRequest{
-		Year:      year,
+		Years:     years,
 		Countries: countries,
+		Mode:      query.Get("mode"),
 	}
 
 	if err := h.validator.Struct(req); err != nil {
-		w.WriteHeader(http.StatusUnprocessableEntity)
-		json.NewEncoder(w).Encode(map[string]string{
-			"error":   "Validation failed",
-			"details": err.Error(),
+		writeValidationError(w, err)
+		return
+	}
+
+	filter, err := parseFilter(query, countries)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	layout := query.Get("calendar")
+	if layout != "" && layout != calendarPerCountry && layout != calendarMerged {
+		http.Error(w, "Invalid calendar parameter, expected per-country or merged", http.StatusBadRequest)
+		return
+	}
+
+	stream := query.Get("stream")
+	if stream != "" && stream != streamNDJSON && stream != streamSSE {
+		http.Error(w, "Invalid stream parameter, expected ndjson or sse", http.StatusBadRequest)
+		return
+	}
+
+	// Negotiate before fetching so that an unacceptable request costs nothing
+	var mediaType string
+	if stream == "" {
+		offers := append(render.Offers(HolidaysResponse{}), ical.ContentType, eventStream)
+		mediaType = render.Negotiate(r, offers...)
+		if mediaType == "" {
+			http.Error(w, "Not acceptable, supported types are "+strings.Join(offers, ", "), http.StatusNotAcceptable)
+			return
+		}
+		if mediaType == eventStream {
+			stream = streamSSE
+		}
+	}
+
+	// A calendar file holds one calendar, so several countries are merged
+	// into it unless the client asked for something that cannot be written
+	if mediaType == ical.ContentType {
+		if req.Mode != "" {
+			http.Error(w, "Aggregation modes cannot be written as a calendar", http.StatusBadRequest)
+			return
+		}
+		if layout == calendarPerCountry && len(countries) > 1 {
+			http.Error(w, "A per-country calendar needs a single country, use calendar=merged for several", http.StatusBadRequest)
+			return
+		}
+		if layout == "" && len(countries) > 1 {
+			layout = calendarMerged
+		}
+	}
+
+	if stream != "" {
+		if req.Mode != "" {
+			http.Error(w, "Aggregation modes need every country and cannot be streamed", http.StatusBadRequest)
+			return
+		}
+		h.streamHolidays(w, r, streamRequest{
+			format:    stream,
+			years:     years,
+			countries: countries,
+			filter:    filter,
+			from:      from,
+			to:        to,
 		})
 		return
 	}
 
 	// Get holidays for all countries concurrently
-	results := h.service.GetHolidaysForCountries(r.Context(), year, countries)
+	var results []holidays.CountryResult
+	if len(years) > 1 {
+		results = holidays.GetHolidaysForYears(r.Context(), h.service, years, countries)
+	} else {
+		results = h.service.GetHolidaysForCountries(r.Context(), years[0], countries)
+	}
 
-	// Send response
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(HolidaysResponse{
-		Year:    year,
+	// Subdivisions are checked against everything fetched, before the
+	// holidays are narrowed down
+	for i, result := range results {
+		if result.Error != "" {
+			continue
+		}
+		if err := filter.Validate(result.CountryCode, result.Holidays); err != nil {
+			writeValidationError(w, err)
+			return
+		}
+		results[i].Holidays = filter.Apply(result.CountryCode, result.Holidays)
+	}
+	if ranged {
+		results = holidays.InRange(results, from, to)
+	}
+
+	if mediaType == ical.ContentType {
+		writeICS(w, results, layout, h.now())
+		return
+	}
+
+	response := HolidaysResponse{
+		Year:    years[0],
+		Mode:    req.Mode,
 		Results: results,
-	})
+	}
+	if len(years) > 1 {
+		response.Years = years
+	}
+	if ranged {
+		response.From = from.Format(holidays.DateLayout)
+		response.To = to.Format(holidays.DateLayout)
+	}
+	if req.Mode != "" {
+		dates, err := holidays.Aggregate(req.Mode, results)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+		response.Dates = dates
+	}
+
+	// Send response
+	render.Encode(w, mediaType, http.StatusOK, response)
+}
+
+// parsePeriod reads either one or more year parameters or an inclusive
+// from/to date range, in which case the years it touches are returned. It
+// writes the error response itself and reports false on invalid input.
+func parsePeriod(w http.ResponseWriter, query url.Values) ([]int, time.Time, time.Time, bool) {
+	var from, to time.Time
+
+	if query.Has("from") || query.Has("to") {
+		if query.Has("year") {
+			http.Error(w, "Use either year or from and to, not both", http.StatusBadRequest)
+			return nil, from, to, false
+		}
+		var err error
+		if from, err = time.Parse(holidays.DateLayout, query.Get("from")); err != nil {
+			http.Error(w, "Invalid from parameter, expected YYYY-MM-DD", http.StatusBadRequest)
+			return nil, from, to, false
+		}
+		if to, err = time.Parse(holidays.DateLayout, query.Get("to")); err != nil {
+			http.Error(w, "Invalid to parameter, expected YYYY-MM-DD", http.StatusBadRequest)
+			return nil, from, to, false
+		}
+		if to.Before(from) {
+			http.Error(w, "The to date must not be before from", http.StatusBadRequest)
+			return nil, from, to, false
+		}
+		return holidays.YearsBetween(from, to), from, to, true
+	}
+
+	var years []int
+	for _, value := range query["year"] {
+		year, err := strconv.Atoi(value)
+		if err != nil {
+			http.Error(w, "Invalid year parameter", http.StatusBadRequest)
+			return nil, from, to, false
+		}
+		if !containsYear(years, year) {
+			years = append(years, year)
+		}
+	}
+	if len(years) == 0 {
+		http.Error(w, "Invalid year parameter", http.StatusBadRequest)
+		return nil, from, to, false
+	}
+	sort.Ints(years)
+	return years, from, to, true
+}
+
+func containsYear(years []int, year int) bool {
+	for _, y := range years {
+		if y == year {
+			return true
+		}
+	}
+	return false
 }
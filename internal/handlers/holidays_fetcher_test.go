@@ -6,7 +6,9 @@ import (
 	"kln-test/internal/holidays"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 )
 
 type mockHolidaysService struct {
@@ -61,9 +63,84 @@ func TestHolidaysHandler(t *testing.T) {
 			url:        "/public-holidays?year=1000&country=CA",
 			wantStatus: http.StatusUnprocessableEntity,
 		},
+		{
+			name:       "intersect mode",
+			url:        "/public-holidays?year=2025&country=CA&country=DE&mode=intersect",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "unknown mode",
+			url:        "/public-holidays?year=2025&country=CA&country=DE&mode=merge",
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "multiple years",
+			url:        "/public-holidays?year=2025&year=2026&country=CA",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "date range",
+			url:        "/public-holidays?from=2025-11-01&to=2026-01-30&country=CA",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "range and year",
+			url:        "/public-holidays?year=2025&from=2025-11-01&to=2026-01-30&country=CA",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "range ends before it starts",
+			url:        "/public-holidays?from=2026-01-30&to=2025-11-01&country=CA",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "range too long",
+			url:        "/public-holidays?from=2000-01-01&to=2025-01-01&country=CA",
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "invalid subdivision",
+			url:        "/public-holidays?year=2025&country=CA&subdivision=Ontario",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "subdivision of another country",
+			url:        "/public-holidays?year=2025&country=CA&subdivision=DE-BY",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "subdivision missing from the data",
+			url:        "/public-holidays?year=2025&country=NL&subdivision=NL-NH",
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "listed subdivision without regional holidays",
+			url:        "/public-holidays?year=2025&country=CA&subdivision=CA-ON",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "unlisted subdivision of a listed country",
+			url:        "/public-holidays?year=2025&country=DE&subdivision=DE-XX",
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "type filters",
+			url:        "/public-holidays?year=2025&country=CA&type=Public&excludeType=bank",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "unknown type",
+			url:        "/public-holidays?year=2025&country=CA&excludeType=Religious",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "too many countries",
+			url:        "/public-holidays?year=2025&country=CA&country=DE&country=FR&country=GB",
+			wantStatus: http.StatusBadRequest,
+		},
 	}
 
-	handler := NewHolidaysFetchHandler(&mockHolidaysService{})
+	handler := NewHolidaysFetchHandler(&mockHolidaysService{}, WithMaxCountries(3))
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -76,6 +153,12 @@ func TestHolidaysHandler(t *testing.T) {
 				t.Errorf("Expected status code %d, got %d", tt.wantStatus, rec.Code)
 			}
 
+			if rec.Code == http.StatusUnprocessableEntity {
+				if got := rec.Header().Get("Content-Type"); got != "application/json" {
+					t.Errorf("Expected Content-Type application/json, got %q", got)
+				}
+			}
+
 			if rec.Code == http.StatusOK {
 				var response map[string]interface{}
 				if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
@@ -88,7 +171,115 @@ func TestHolidaysHandler(t *testing.T) {
 				if _, ok := response["results"]; !ok {
 					t.Error("Response missing results field")
 				}
+				if _, ok := response["dates"]; ok != (response["mode"] != nil) {
+					t.Error("Expected dates only when a mode is requested")
+				}
 			}
 		})
 	}
 }
+
+func TestHolidaysHandlerModeWithFailedCountry(t *testing.T) {
+	handler := NewHolidaysFetchHandler(holidays.NewService(failingClient{}))
+
+	req := httptest.NewRequest(http.MethodGet, "/public-holidays?year=2025&country=CA&country=DE&mode=intersect", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadGateway {
+		t.Errorf("Expected status code %d, got %d", http.StatusBadGateway, rec.Code)
+	}
+}
+
+// bankHolidayRules are UK holidays of each type, kept apart from
+// DefaultRules so that adding holidays there does not move these dates
+var bankHolidayRules = []holidays.Rule{
+	{Name: "New Year's Day", Date: holidays.FixedDate(time.January, 1), Observed: holidays.ObserveNextWorkday, Types: []string{"Public", "Bank"}},
+	{Name: "Good Friday", Date: holidays.EasterOffset(-2)},
+	{Name: "Easter Monday", Date: holidays.EasterOffset(1), Types: []string{"Public", "Bank"}, Counties: []string{"GB-ENG", "GB-WLS", "GB-NIR"}},
+	{Name: "Early May Bank Holiday", Date: holidays.NthWeekday(1, time.Monday, time.May), Types: []string{"Bank"}},
+	{Name: "Spring Bank Holiday", Date: holidays.LastWeekday(time.Monday, time.May), Types: []string{"Bank"}},
+	{Name: "Summer Bank Holiday", Date: holidays.NthWeekday(1, time.Monday, time.August), Types: []string{"Bank"}, Counties: []string{"GB-SCT"}},
+	{Name: "Summer Bank Holiday", Date: holidays.LastWeekday(time.Monday, time.August), Types: []string{"Bank"}, Counties: []string{"GB-ENG", "GB-WLS", "GB-NIR"}},
+	{Name: "Saint Andrew's Day", Date: holidays.FixedDate(time.November, 30), Observed: holidays.ObserveNextMonday, Types: []string{"Bank"}, Counties: []string{"GB-SCT"}},
+	{Name: "Christmas Day", Date: holidays.FixedDate(time.December, 25), Observed: holidays.ObserveNextWorkday},
+	{Name: "St. Stephen's Day", Date: holidays.FixedDate(time.December, 26), Observed: holidays.ObserveNextWorkday, Types: []string{"Public", "Bank"}},
+}
+
+func TestHolidaysHandlerTypeFilters(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{
+			name:  "bank holidays only",
+			query: "&type=Bank",
+			want:  []string{"2025-01-01", "2025-04-21", "2025-05-05", "2025-05-26", "2025-08-04", "2025-08-25", "2025-12-01", "2025-12-26"},
+		},
+		{
+			name:  "without bank-only holidays",
+			query: "&excludeType=Bank",
+			want:  []string{"2025-01-01", "2025-04-18", "2025-04-21", "2025-12-25", "2025-12-26"},
+		},
+		{
+			name:  "public holidays",
+			query: "&type=Public",
+			want:  []string{"2025-01-01", "2025-04-18", "2025-04-21", "2025-12-25", "2025-12-26"},
+		},
+	}
+
+	handler := NewHolidaysFetchHandler(holidays.NewService(holidays.NewRuleClient(map[string][]holidays.Rule{"GB": bankHolidayRules})))
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/public-holidays?year=2025&country=GB"+tt.query, nil)
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusOK {
+				t.Fatalf("Expected status code %d, got %d", http.StatusOK, rec.Code)
+			}
+			var response HolidaysResponse
+			if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			var got []string
+			for _, holiday := range response.Results[0].Holidays {
+				got = append(got, holiday.Date)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestHolidaysHandlerHolidayFields(t *testing.T) {
+	handler := NewHolidaysFetchHandler(holidays.NewService(holidays.NewRuleClient(map[string][]holidays.Rule{"GB": bankHolidayRules})))
+
+	req := httptest.NewRequest(http.MethodGet, "/public-holidays?year=2025&country=GB", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	var response struct {
+		Results []struct {
+			Holidays []map[string]json.RawMessage `json:"holidays"`
+		} `json:"results"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	holiday := response.Results[0].Holidays[0]
+
+	// "type" is deprecated but still written for clients of earlier versions
+	for _, field := range []string{"date", "localName", "name", "countryCode", "fixed", "global", "types", "type"} {
+		if _, ok := holiday[field]; !ok {
+			t.Errorf("Expected field %q in %v", field, holiday)
+		}
+	}
+	if string(holiday["types"]) != `["Public","Bank"]` || string(holiday["type"]) != `["Public","Bank"]` {
+		t.Errorf("Expected both type fields to be [\"Public\",\"Bank\"], got %s and %s", holiday["types"], holiday["type"])
+	}
+}
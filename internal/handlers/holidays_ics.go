@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"kln-test/internal/holidays"
+	"kln-test/internal/ical"
+	"kln-test/internal/render"
+)
+
+func init() {
+	render.RegisterFormat("ics", ical.ContentType)
+}
+
+// Calendar layouts for iCalendar responses
+const (
+	calendarPerCountry = "per-country"
+	calendarMerged     = "merged"
+)
+
+// writeICS writes results as one calendar per country, or as a single
+// merged calendar. The handler only asks for per-country calendars when
+// there is one country, since clients read a single calendar per file.
+// A calendar client replaces its copy with what it
+// downloads, so a partial answer would delete holidays: if any country
+// failed, nothing is written and the client keeps its previous copy.
+func writeICS(w http.ResponseWriter, results []holidays.CountryResult, layout string, stamp time.Time) {
+	for _, result := range results {
+		if result.Error != "" {
+			http.Error(w, fmt.Sprintf("Failed to get holidays for %s: %s", result.CountryCode, result.Error), http.StatusBadGateway)
+			return
+		}
+	}
+
+	var calendars []ical.Calendar
+	if layout == calendarMerged {
+		merged := ical.Calendar{}
+		var codes []string
+		for _, result := range results {
+			country := strings.ToUpper(result.CountryCode)
+			codes = append(codes, country)
+			merged.Events = append(merged.Events, holidayEvents(country, result.Holidays, true)...)
+		}
+		sort.SliceStable(merged.Events, func(i, j int) bool {
+			return merged.Events[i].Date.Before(merged.Events[j].Date)
+		})
+		merged.Name = "Public holidays " + strings.Join(codes, ", ")
+		calendars = append(calendars, merged)
+	} else {
+		for _, result := range results {
+			country := strings.ToUpper(result.CountryCode)
+			calendars = append(calendars, ical.Calendar{
+				Name:   "Public holidays " + country,
+				Events: holidayEvents(country, result.Holidays, false),
+			})
+		}
+	}
+
+	var body bytes.Buffer
+	if err := ical.Write(&body, stamp, calendars...); err != nil {
+		http.Error(w, "Failed to write calendar: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", ical.ContentType+"; charset=utf-8")
+	w.Header().Set("Content-Disposition", `inline; filename="holidays.ics"`)
+	w.Write(body.Bytes())
+}
+
+// holidayEvents turns a country's holidays into one event per date, so that
+// each event's UID depends only on the country and date. Holidays sharing a
+// date are combined into a single event.
+func holidayEvents(country string, list []holidays.Holiday, withCountry bool) []ical.Event {
+	var (
+		events []ical.Event
+		byDate = make(map[string]int)
+	)
+	for _, holiday := range list {
+		date, err := time.Parse(holidays.DateLayout, holiday.Date)
+		if err != nil {
+			continue
+		}
+		summary := holiday.Name
+		if withCountry {
+			summary = fmt.Sprintf("%s (%s)", holiday.Name, country)
+		}
+
+		i, ok := byDate[holiday.Date]
+		if !ok {
+			byDate[holiday.Date] = len(events)
+			events = append(events, ical.Event{
+				UID:         fmt.Sprintf("%s-%s@public-holidays.kln-test", date.Format("20060102"), country),
+				Date:        date,
+				Summary:     summary,
+				Description: holiday.LocalName,
+				Categories:  append([]string(nil), holiday.Types...),
+			})
+			continue
+		}
+
+		event := &events[i]
+		event.Summary += " / " + summary
+		if holiday.LocalName != "" {
+			event.Description = strings.TrimPrefix(event.Description+" / "+holiday.LocalName, " / ")
+		}
+		for _, holidayType := range holiday.Types {
+			if !containsFold(event.Categories, holidayType) {
+				event.Categories = append(event.Categories, holidayType)
+			}
+		}
+	}
+	return events
+}
@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHolidaysHandlerICS(t *testing.T) {
+	tests := []struct {
+		name          string
+		url           string
+		accept        string
+		wantStatus    int
+		wantCalendars int
+		wantSummary   string
+	}{
+		{
+			name:          "format parameter",
+			url:           "/public-holidays?year=2025&country=CA&format=ics",
+			wantStatus:    http.StatusOK,
+			wantCalendars: 1,
+			wantSummary:   "SUMMARY:New Year's Day\r\n",
+		},
+		{
+			name:          "several countries are merged by default",
+			url:           "/public-holidays?year=2025&country=CA&country=DE&format=ics",
+			wantStatus:    http.StatusOK,
+			wantCalendars: 1,
+			wantSummary:   "SUMMARY:New Year's Day (DE)\r\n",
+		},
+		{
+			name:          "repeated country",
+			url:           "/public-holidays?year=2025&country=CA&country=ca&format=ics",
+			wantStatus:    http.StatusOK,
+			wantCalendars: 1,
+			wantSummary:   "SUMMARY:New Year's Day\r\n",
+		},
+		{
+			name:       "per-country calendar for several countries",
+			url:        "/public-holidays?year=2025&country=CA&country=DE&format=ics&calendar=per-country",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "mode",
+			url:        "/public-holidays?year=2025&country=CA&country=DE&format=ics&mode=union",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:          "accept header",
+			url:           "/public-holidays?year=2025&country=CA",
+			accept:        "text/calendar",
+			wantStatus:    http.StatusOK,
+			wantCalendars: 1,
+			wantSummary:   "SUMMARY:New Year's Day\r\n",
+		},
+		{
+			name:          "merged calendar",
+			url:           "/public-holidays?year=2025&country=CA&country=DE&format=ics&calendar=merged",
+			wantStatus:    http.StatusOK,
+			wantCalendars: 1,
+			wantSummary:   "SUMMARY:New Year's Day (DE)\r\n",
+		},
+		{
+			name:       "invalid calendar",
+			url:        "/public-holidays?year=2025&country=CA&format=ics&calendar=weekly",
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	handler := NewHolidaysFetchHandler(&mockHolidaysService{})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("Expected status code %d, got %d", tt.wantStatus, rec.Code)
+			}
+			if rec.Code != http.StatusOK {
+				return
+			}
+
+			if contentType := rec.Header().Get("Content-Type"); !strings.HasPrefix(contentType, "text/calendar") {
+				t.Errorf("Expected text/calendar, got %s", contentType)
+			}
+			body := rec.Body.String()
+			if got := strings.Count(body, "BEGIN:VCALENDAR"); got != tt.wantCalendars {
+				t.Errorf("Expected %d calendars, got %d", tt.wantCalendars, got)
+			}
+			if !strings.Contains(body, "UID:20250101-CA@public-holidays.kln-test\r\n") {
+				t.Errorf("Expected a UID for CA on 2025-01-01, got:\n%s", body)
+			}
+			if got := strings.Count(body, "UID:20250101-CA@"); got != 1 {
+				t.Errorf("Expected 1 event for CA on 2025-01-01, got %d", got)
+			}
+			if !strings.Contains(body, tt.wantSummary) {
+				t.Errorf("Expected %q, got:\n%s", tt.wantSummary, body)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"net/http"
+
+	"kln-test/internal/holidays"
+	"kln-test/internal/render"
+)
+
+// HolidaysStatusResponse reports the state of the holiday data sources.
+// Cache is omitted when caching is disabled.
+type HolidaysStatusResponse struct {
+	Cache     *holidays.CacheStats      `json:"cache,omitempty"`
+	Providers []holidays.ProviderHealth `json:"providers,omitempty"`
+}
+
+// HolidaysStatusHandler reports cache effectiveness and provider health
+// for operators
+type HolidaysStatusHandler struct {
+	cache    *holidays.CachingClient
+	failover *holidays.FailoverClient
+}
+
+// HolidaysStatusOption customises a HolidaysStatusHandler
+type HolidaysStatusOption func(*HolidaysStatusHandler)
+
+// WithCacheStats reports the hit and miss counters of cache
+func WithCacheStats(cache *holidays.CachingClient) HolidaysStatusOption {
+	return func(h *HolidaysStatusHandler) {
+		h.cache = cache
+	}
+}
+
+// WithProviderHealth reports the circuit breaker state of every provider
+func WithProviderHealth(failover *holidays.FailoverClient) HolidaysStatusOption {
+	return func(h *HolidaysStatusHandler) {
+		h.failover = failover
+	}
+}
+
+// NewHolidaysStatusHandler creates a new holiday status handler
+func NewHolidaysStatusHandler(opts ...HolidaysStatusOption) *HolidaysStatusHandler {
+	h := &HolidaysStatusHandler{}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// ServeHTTP handles HTTP requests for the holiday data source status
+func (h *HolidaysStatusHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var response HolidaysStatusResponse
+	if h.cache != nil {
+		stats := h.cache.Stats()
+		response.Cache = &stats
+	}
+	if h.failover != nil {
+		response.Providers = h.failover.Health()
+	}
+	render.Write(w, r, http.StatusOK, response)
+}
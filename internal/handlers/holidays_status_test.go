@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"kln-test/internal/holidays"
+)
+
+func TestHolidaysStatusHandler(t *testing.T) {
+	cache := holidays.NewCachingClient(holidays.NewRuleClient(holidays.DefaultRules), holidays.CacheOptions{
+		TTL:        time.Minute,
+		MaxEntries: 10,
+	})
+	cache.GetHolidays(context.Background(), 2025, "DE")
+	cache.GetHolidays(context.Background(), 2025, "DE")
+
+	failover := holidays.NewFailoverClient(holidays.BreakerOptions{FailureThreshold: 1, Cooldown: time.Minute},
+		holidays.Provider{Name: "down", Client: failingClient{}},
+		holidays.Provider{Name: "rules", Client: holidays.NewRuleClient(holidays.DefaultRules)},
+	)
+	failover.GetHolidays(context.Background(), 2025, "DE")
+
+	tests := []struct {
+		name       string
+		method     string
+		handler    *HolidaysStatusHandler
+		wantStatus int
+		wantCache  bool
+		wantHealth bool
+	}{
+		{
+			name:       "with cache",
+			method:     http.MethodGet,
+			handler:    NewHolidaysStatusHandler(WithCacheStats(cache)),
+			wantStatus: http.StatusOK,
+			wantCache:  true,
+		},
+		{
+			name:       "with providers",
+			method:     http.MethodGet,
+			handler:    NewHolidaysStatusHandler(WithProviderHealth(failover)),
+			wantStatus: http.StatusOK,
+			wantHealth: true,
+		},
+		{
+			name:       "cache disabled",
+			method:     http.MethodGet,
+			handler:    NewHolidaysStatusHandler(),
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "wrong method",
+			method:     http.MethodPost,
+			handler:    NewHolidaysStatusHandler(),
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/public-holidays/status", nil)
+			rec := httptest.NewRecorder()
+
+			tt.handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("Expected status code %d, got %d", tt.wantStatus, rec.Code)
+			}
+			if rec.Code != http.StatusOK {
+				return
+			}
+
+			var response HolidaysStatusResponse
+			if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if (response.Cache != nil) != tt.wantCache {
+				t.Fatalf("Expected cache stats: %t, got %+v", tt.wantCache, response.Cache)
+			}
+			if tt.wantHealth {
+				if len(response.Providers) != 2 {
+					t.Fatalf("Expected 2 providers, got %+v", response.Providers)
+				}
+				if response.Providers[0].State != holidays.CircuitOpen || response.Providers[1].State != holidays.CircuitClosed {
+					t.Errorf("Unexpected provider health: %+v", response.Providers)
+				}
+			} else if response.Providers != nil {
+				t.Errorf("Expected no provider health, got %+v", response.Providers)
+			}
+			if tt.wantCache && (response.Cache.Hits != 1 || response.Cache.Misses != 1 || response.Cache.Entries != 1) {
+				t.Errorf("Unexpected cache stats: %+v", *response.Cache)
+			}
+		})
+	}
+}
+
+// failingClient fails every lookup with a temporary error
+type failingClient struct{}
+
+func (failingClient) GetHolidays(ctx context.Context, year int, countryCode string) ([]holidays.Holiday, error) {
+	return nil, errors.New("upstream down")
+}
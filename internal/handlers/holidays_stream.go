@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"kln-test/internal/holidays"
+	"kln-test/internal/render"
+)
+
+// Streaming formats for the stream parameter
+const (
+	streamNDJSON = "ndjson"
+	streamSSE    = "sse"
+)
+
+// eventStream is the media type of Server-Sent Events
+const eventStream = "text/event-stream"
+
+// HolidaysStreamSummary is the last record of a streamed holidays response
+type HolidaysStreamSummary struct {
+	Year      int    `json:"year"`
+	Years     []int  `json:"years,omitempty"`
+	From      string `json:"from,omitempty"`
+	To        string `json:"to,omitempty"`
+	Countries int    `json:"countries"`
+	Failed    int    `json:"failed"`
+	Stale     int    `json:"stale"`
+	ElapsedMs int64  `json:"elapsedMs"`
+}
+
+// streamRequest is what a streamed response needs to know about the request
+type streamRequest struct {
+	format    string
+	years     []int
+	countries []string
+	filter    holidays.Filter
+	from, to  time.Time
+}
+
+// streamHolidays writes each country's result as soon as its fetch is done,
+// followed by a summary. The status is sent before anything is fetched, so
+// problems with a single country, including an unknown subdivision, are
+// reported in that country's error instead.
+func (h *HolidaysFetchHandler) streamHolidays(w http.ResponseWriter, r *http.Request, req streamRequest) {
+	start := h.now()
+	flusher := http.NewResponseController(w)
+
+	if req.format == streamSSE {
+		w.Header().Set("Content-Type", eventStream)
+	} else {
+		w.Header().Set("Content-Type", render.NDJSON)
+	}
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	summary := HolidaysStreamSummary{Year: req.years[0]}
+	if len(req.years) > 1 {
+		summary.Years = req.years
+	}
+	if !req.from.IsZero() {
+		summary.From = req.from.Format(holidays.DateLayout)
+		summary.To = req.to.Format(holidays.DateLayout)
+	}
+
+	holidays.StreamHolidaysForYears(r.Context(), h.service, req.years, req.countries, func(result holidays.CountryResult) {
+		if result.Error == "" {
+			if err := req.filter.Validate(result.CountryCode, result.Holidays); err != nil {
+				result.Holidays = nil
+				result.Error = err.Error()
+			} else {
+				result.Holidays = req.filter.Apply(result.CountryCode, result.Holidays)
+			}
+		}
+		if !req.from.IsZero() {
+			result = holidays.InRange([]holidays.CountryResult{result}, req.from, req.to)[0]
+		}
+
+		summary.Countries++
+		if result.Error != "" {
+			summary.Failed++
+		}
+		if result.Stale {
+			summary.Stale++
+		}
+		writeStreamRecord(w, req.format, "result", result)
+		flusher.Flush()
+	})
+
+	summary.ElapsedMs = h.now().Sub(start).Milliseconds()
+	if req.format == streamSSE {
+		writeStreamRecord(w, req.format, "summary", summary)
+	} else {
+		writeStreamRecord(w, req.format, "summary", map[string]HolidaysStreamSummary{"summary": summary})
+	}
+	flusher.Flush()
+}
+
+// writeStreamRecord writes v as one NDJSON line, or as a Server-Sent Event
+// named event
+func writeStreamRecord(w http.ResponseWriter, format, event string, v interface{}) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return
+	}
+	if format == streamSSE {
+		fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data)
+		return
+	}
+	w.Write(append(data, '\n'))
+}
@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"kln-test/internal/holidays"
+)
+
+func TestHolidaysHandlerStream(t *testing.T) {
+	tests := []struct {
+		name            string
+		url             string
+		accept          string
+		wantStatus      int
+		wantContentType string
+		wantResults     int
+	}{
+		{
+			name:            "ndjson",
+			url:             "/public-holidays?year=2025&country=CA&country=DE&stream=ndjson",
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/x-ndjson",
+			wantResults:     2,
+		},
+		{
+			name:            "several years",
+			url:             "/public-holidays?year=2025&year=2026&country=CA&country=DE&stream=ndjson",
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/x-ndjson",
+			wantResults:     2,
+		},
+		{
+			name:            "server-sent events",
+			url:             "/public-holidays?year=2025&country=CA&country=DE&stream=sse",
+			wantStatus:      http.StatusOK,
+			wantContentType: "text/event-stream",
+			wantResults:     2,
+		},
+		{
+			name:            "server-sent events by accept header",
+			url:             "/public-holidays?year=2025&country=CA",
+			accept:          "text/event-stream",
+			wantStatus:      http.StatusOK,
+			wantContentType: "text/event-stream",
+			wantResults:     1,
+		},
+		{
+			name:       "invalid stream",
+			url:        "/public-holidays?year=2025&country=CA&stream=websocket",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "aggregation mode",
+			url:        "/public-holidays?year=2025&country=CA&country=DE&mode=union&stream=ndjson",
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	handler := NewHolidaysFetchHandler(&mockHolidaysService{})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, rec.Code)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			if got := rec.Header().Get("Content-Type"); got != tt.wantContentType {
+				t.Errorf("Expected content type %q, got %q", tt.wantContentType, got)
+			}
+			if !rec.Flushed {
+				t.Error("Expected the response to be flushed")
+			}
+
+			body := rec.Body.String()
+			if tt.wantContentType == eventStream {
+				if got := strings.Count(body, "event: result\n"); got != tt.wantResults {
+					t.Errorf("Expected %d result events, got %d:\n%s", tt.wantResults, got, body)
+				}
+				if !strings.HasSuffix(body, "\n\n") || !strings.Contains(body, "event: summary\ndata: {") {
+					t.Errorf("Expected a closing summary event, got:\n%s", body)
+				}
+				return
+			}
+
+			lines := strings.Split(strings.TrimSuffix(body, "\n"), "\n")
+			if len(lines) != tt.wantResults+1 {
+				t.Fatalf("Expected %d results and a summary, got:\n%s", tt.wantResults, body)
+			}
+			var last struct {
+				Summary *HolidaysStreamSummary `json:"summary"`
+			}
+			if err := json.Unmarshal([]byte(lines[len(lines)-1]), &last); err != nil || last.Summary == nil {
+				t.Fatalf("Expected a summary as the last record, got %q", lines[len(lines)-1])
+			}
+			if last.Summary.Countries != tt.wantResults || last.Summary.Failed != 0 {
+				t.Errorf("Unexpected summary: %+v", last.Summary)
+			}
+		})
+	}
+}
+
+func TestHolidaysHandlerStreamReportsUnknownSubdivision(t *testing.T) {
+	handler := NewHolidaysFetchHandler(&mockHolidaysService{})
+	req := httptest.NewRequest(http.MethodGet, "/public-holidays?year=2025&country=CA&country=NL&subdivision=NL-NH&stream=ndjson", nil)
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+	decoder := json.NewDecoder(rec.Body)
+	failed := make(map[string]bool)
+	for i := 0; i < 2; i++ {
+		var result holidays.CountryResult
+		if err := decoder.Decode(&result); err != nil {
+			t.Fatalf("Failed to decode result: %v", err)
+		}
+		failed[result.CountryCode] = result.Error != ""
+	}
+	if !failed["NL"] || failed["CA"] {
+		t.Errorf("Expected only NL to report the unknown subdivision, got %v", failed)
+	}
+}
@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"kln-test/internal/holidays"
+	"kln-test/internal/render"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// LongWeekendsRequest represents the query parameters for the long weekends endpoint
+type LongWeekendsRequest struct {
+	Country string `validate:"required,len=2"`
+	Year    int    `validate:"required,min=1900,max=2100"`
+}
+
+// LongWeekendsResponse lists the long weekends of a country in a year
+type LongWeekendsResponse struct {
+	Country      string                 `json:"country"`
+	Year         int                    `json:"year"`
+	LongWeekends []holidays.LongWeekend `json:"longWeekends"`
+}
+
+// LongWeekendsHandler handles long weekend and bridge day requests
+type LongWeekendsHandler struct {
+	validator *validator.Validate
+	calendar  *holidays.Calendar
+}
+
+// NewLongWeekendsHandler creates a new long weekends handler
+func NewLongWeekendsHandler(calendar *holidays.Calendar) *LongWeekendsHandler {
+	return &LongWeekendsHandler{
+		validator: validator.New(),
+		calendar:  calendar,
+	}
+}
+
+// ServeHTTP handles HTTP requests for long weekends
+func (h *LongWeekendsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	year, err := strconv.Atoi(query.Get("year"))
+	if err != nil {
+		http.Error(w, "Invalid year parameter", http.StatusBadRequest)
+		return
+	}
+
+	req := LongWeekendsRequest{
+		Country: strings.ToUpper(query.Get("country")),
+		Year:    year,
+	}
+	if err := h.validator.Struct(req); err != nil {
+		writeValidationError(w, err)
+		return
+	}
+
+	filter, err := parseFilter(query, []string{req.Country})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	weekends, err := h.calendar.Filtered(filter).LongWeekends(r.Context(), req.Country, req.Year)
+	if err != nil {
+		writeCalendarError(w, err)
+		return
+	}
+	if weekends == nil {
+		weekends = []holidays.LongWeekend{}
+	}
+
+	render.Write(w, r, http.StatusOK, LongWeekendsResponse{
+		Country:      req.Country,
+		Year:         req.Year,
+		LongWeekends: weekends,
+	})
+}
@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"kln-test/internal/holidays"
+)
+
+func TestLongWeekendsHandler(t *testing.T) {
+	tests := []struct {
+		name         string
+		url          string
+		wantStatus   int
+		wantWeekends int
+		wantBridges  int
+	}{
+		{
+			name:         "valid request",
+			url:          "/long-weekends?country=DE&year=2025",
+			wantStatus:   http.StatusOK,
+			wantWeekends: 6,
+			wantBridges:  2,
+		},
+		{
+			name:       "unknown country",
+			url:        "/long-weekends?country=zz&year=2025",
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "invalid year",
+			url:        "/long-weekends?country=DE&year=next",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "year out of range",
+			url:        "/long-weekends?country=DE&year=1000",
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+	}
+
+	calendar := holidays.NewCalendar(holidays.NewService(holidays.NewRuleClient(holidays.DefaultRules)))
+	handler := NewLongWeekendsHandler(calendar)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("Expected status code %d, got %d", tt.wantStatus, rec.Code)
+			}
+
+			if rec.Code == http.StatusOK {
+				var response LongWeekendsResponse
+				if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				bridges := 0
+				for _, weekend := range response.LongWeekends {
+					bridges += len(weekend.BridgeDays)
+				}
+				if len(response.LongWeekends) != tt.wantWeekends || bridges != tt.wantBridges {
+					t.Errorf("Expected %d long weekends with %d bridge days, got %d with %d", tt.wantWeekends, tt.wantBridges, len(response.LongWeekends), bridges)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"strconv"
+	"strings"
+
+	"kln-test/internal/holidays"
+)
+
+// holidayColumns are the CSV columns describing a single holiday
+var holidayColumns = []string{"date", "localName", "name", "fixed", "global", "counties", "types"}
+
+// holidayFields flattens a holiday into holidayColumns. Lists are joined
+// with semicolons so that each holiday stays on one row.
+func holidayFields(h holidays.Holiday) []string {
+	return []string{
+		h.Date,
+		h.LocalName,
+		h.Name,
+		strconv.FormatBool(h.Fixed),
+		strconv.FormatBool(h.Global),
+		strings.Join(h.Counties, ";"),
+		strings.Join(h.Types, ";"),
+	}
+}
+
+// CSVHeader names the CSV columns: one row per holiday and country, or one
+// row per date when an aggregation mode was requested
+func (r HolidaysResponse) CSVHeader() []string {
+	if r.Mode != "" {
+		return []string{"date", "countries", "holidays"}
+	}
+	header := append([]string{"countryCode"}, holidayColumns...)
+	return append(header, "provider", "stale", "error")
+}
+
+// CSVRows flattens the results. A country whose lookup failed gets a single
+// row carrying only its error.
+func (r HolidaysResponse) CSVRows() [][]string {
+	var rows [][]string
+	if r.Mode != "" {
+		for _, entry := range r.Dates {
+			names := make([]string, 0, len(entry.Holidays))
+			for _, holiday := range entry.Holidays {
+				names = append(names, holiday.Name)
+			}
+			rows = append(rows, []string{entry.Date, strings.Join(entry.Countries, ";"), strings.Join(names, ";")})
+		}
+		return rows
+	}
+
+	for _, result := range r.Results {
+		source := []string{result.Provider, strconv.FormatBool(result.Stale), result.Error}
+		if result.Error != "" {
+			row := append([]string{result.CountryCode}, make([]string, len(holidayColumns))...)
+			rows = append(rows, append(row, source...))
+			continue
+		}
+		for _, holiday := range result.Holidays {
+			row := append([]string{result.CountryCode}, holidayFields(holiday)...)
+			rows = append(rows, append(row, source...))
+		}
+	}
+	return rows
+}
+
+// Records returns one record per country, or per date when an aggregation
+// mode was requested
+func (r HolidaysResponse) Records() []interface{} {
+	var records []interface{}
+	if r.Mode != "" {
+		for _, entry := range r.Dates {
+			records = append(records, entry)
+		}
+		return records
+	}
+	for _, result := range r.Results {
+		records = append(records, result)
+	}
+	return records
+}
+
+// CSVHeader names the CSV columns, one row per upcoming holiday
+func (r NextHolidaysResponse) CSVHeader() []string {
+	return append([]string{"country"}, holidayColumns...)
+}
+
+// CSVRows flattens the upcoming holidays
+func (r NextHolidaysResponse) CSVRows() [][]string {
+	var rows [][]string
+	for _, holiday := range r.Holidays {
+		rows = append(rows, append([]string{r.Country}, holidayFields(holiday)...))
+	}
+	return rows
+}
+
+// Records returns one record per upcoming holiday
+func (r NextHolidaysResponse) Records() []interface{} {
+	var records []interface{}
+	for _, holiday := range r.Holidays {
+		records = append(records, holiday)
+	}
+	return records
+}
+
+// CSVHeader names the CSV columns, one row per long weekend
+func (r LongWeekendsResponse) CSVHeader() []string {
+	return []string{"country", "startDate", "endDate", "dayCount", "needBridgeDay", "bridgeDays", "holidays"}
+}
+
+// CSVRows flattens the long weekends, listing holidays by name
+func (r LongWeekendsResponse) CSVRows() [][]string {
+	var rows [][]string
+	for _, weekend := range r.LongWeekends {
+		names := make([]string, 0, len(weekend.Holidays))
+		for _, holiday := range weekend.Holidays {
+			names = append(names, holiday.Name)
+		}
+		rows = append(rows, []string{
+			r.Country,
+			weekend.StartDate,
+			weekend.EndDate,
+			strconv.Itoa(weekend.DayCount),
+			strconv.FormatBool(weekend.NeedBridgeDay),
+			strings.Join(weekend.BridgeDays, ";"),
+			strings.Join(names, ";"),
+		})
+	}
+	return rows
+}
+
+// Records returns one record per long weekend
+func (r LongWeekendsResponse) Records() []interface{} {
+	var records []interface{}
+	for _, weekend := range r.LongWeekends {
+		records = append(records, weekend)
+	}
+	return records
+}
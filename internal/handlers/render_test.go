@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"kln-test/internal/holidays"
+)
+
+func TestHolidaysHandlerFormats(t *testing.T) {
+	tests := []struct {
+		name            string
+		url             string
+		accept          string
+		wantStatus      int
+		wantContentType string
+		wantLines       int
+	}{
+		{
+			name:            "csv",
+			url:             "/public-holidays?year=2025&country=CA&country=DE",
+			accept:          "text/csv",
+			wantStatus:      http.StatusOK,
+			wantContentType: "text/csv; charset=utf-8",
+			wantLines:       3,
+		},
+		{
+			name:            "csv by format parameter",
+			url:             "/public-holidays?year=2025&country=CA&format=csv",
+			wantStatus:      http.StatusOK,
+			wantContentType: "text/csv; charset=utf-8",
+			wantLines:       2,
+		},
+		{
+			name:            "aggregated csv",
+			url:             "/public-holidays?year=2025&country=CA&country=DE&mode=union&format=csv",
+			wantStatus:      http.StatusOK,
+			wantContentType: "text/csv; charset=utf-8",
+			wantLines:       2,
+		},
+		{
+			name:            "ndjson",
+			url:             "/public-holidays?year=2025&country=CA&country=DE",
+			accept:          "application/x-ndjson",
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/x-ndjson",
+			wantLines:       2,
+		},
+		{
+			name:       "not acceptable",
+			url:        "/public-holidays?year=2025&country=CA",
+			accept:     "application/xml",
+			wantStatus: http.StatusNotAcceptable,
+		},
+		{
+			name:       "unknown format",
+			url:        "/public-holidays?year=2025&country=CA&format=xml",
+			wantStatus: http.StatusNotAcceptable,
+		},
+	}
+
+	handler := NewHolidaysFetchHandler(&mockHolidaysService{})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, rec.Code)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			if got := rec.Header().Get("Content-Type"); got != tt.wantContentType {
+				t.Errorf("Expected content type %q, got %q", tt.wantContentType, got)
+			}
+			lines := strings.Split(strings.TrimSuffix(rec.Body.String(), "\n"), "\n")
+			if len(lines) != tt.wantLines {
+				t.Errorf("Expected %d lines, got %d: %q", tt.wantLines, len(lines), rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestHolidaysResponseCSVRows(t *testing.T) {
+	response := HolidaysResponse{
+		Year: 2025,
+		Results: []holidays.CountryResult{
+			{
+				CountryCode: "DE",
+				Provider:    "nager",
+				Stale:       true,
+				Holidays: []holidays.Holiday{{
+					Date:     "2025-10-31",
+					Name:     "Reformation Day",
+					Counties: []string{"DE-BB", "DE-SN"},
+					Types:    []string{"Public"},
+				}},
+			},
+			{CountryCode: "XX", Error: "unknown country"},
+		},
+	}
+
+	header := response.CSVHeader()
+	rows := response.CSVRows()
+	if len(rows) != 2 {
+		t.Fatalf("Expected 2 rows, got %d", len(rows))
+	}
+	for _, row := range rows {
+		if len(row) != len(header) {
+			t.Errorf("Expected %d columns, got %d: %q", len(header), len(row), row)
+		}
+	}
+
+	want := []string{"DE", "2025-10-31", "", "Reformation Day", "false", "false", "DE-BB;DE-SN", "Public", "nager", "true", ""}
+	if strings.Join(rows[0], "|") != strings.Join(want, "|") {
+		t.Errorf("Expected row %q, got %q", want, rows[0])
+	}
+	if rows[1][0] != "XX" || rows[1][len(header)-1] != "unknown country" {
+		t.Errorf("Expected the failed country to carry its error, got %q", rows[1])
+	}
+}
+
+func TestHolidaysHandlerCSVParses(t *testing.T) {
+	handler := NewHolidaysFetchHandler(&mockHolidaysService{})
+	req := httptest.NewRequest(http.MethodGet, "/public-holidays?year=2025&country=CA&format=csv", nil)
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	records, err := csv.NewReader(rec.Body).ReadAll()
+	if err != nil {
+		t.Fatalf("Failed to parse CSV: %v", err)
+	}
+	if records[0][0] != "countryCode" || records[1][0] != "CA" || records[1][3] != "New Year's Day" {
+		t.Errorf("Unexpected CSV: %q", records)
+	}
+}
+
+func TestHolidaysHandlerNDJSONRecords(t *testing.T) {
+	handler := NewHolidaysFetchHandler(&mockHolidaysService{})
+	req := httptest.NewRequest(http.MethodGet, "/public-holidays?year=2025&country=CA&country=DE&format=ndjson", nil)
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	decoder := json.NewDecoder(rec.Body)
+	var codes []string
+	for decoder.More() {
+		var result holidays.CountryResult
+		if err := decoder.Decode(&result); err != nil {
+			t.Fatalf("Failed to decode record: %v", err)
+		}
+		codes = append(codes, result.CountryCode)
+	}
+	if strings.Join(codes, ",") != "CA,DE" {
+		t.Errorf("Expected one record per country, got %v", codes)
+	}
+}
@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"kln-test/internal/holidays"
+	"kln-test/internal/render"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// ShipmentETARequest represents the query parameters for the shipment ETA endpoint
+type ShipmentETARequest struct {
+	Origin      string   `validate:"required,len=2"`
+	Destination string   `validate:"required,len=2"`
+	Transit     []string `validate:"max=10,dive,required,len=2"`
+	TransitDays int      `validate:"min=0,max=365"`
+}
+
+// ShipmentETAResponse is the adjusted schedule of a shipment. UnadjustedETA
+// is the pickup date plus the transit days, ignoring holidays.
+type ShipmentETAResponse struct {
+	Origin         string          `json:"origin"`
+	Destination    string          `json:"destination"`
+	Transit        []string        `json:"transit,omitempty"`
+	Pickup         string          `json:"pickup"`
+	AdjustedPickup string          `json:"adjustedPickup"`
+	TransitDays    int             `json:"transitDays"`
+	UnadjustedETA  string          `json:"unadjustedEta"`
+	ETA            string          `json:"eta"`
+	DelayDays      int             `json:"delayDays"`
+	Holidays       []holidays.Slip `json:"holidays"`
+}
+
+// ShipmentETAHandler estimates delivery dates around holidays
+type ShipmentETAHandler struct {
+	validator *validator.Validate
+	calendar  *holidays.Calendar
+}
+
+// NewShipmentETAHandler creates a new shipment ETA handler
+func NewShipmentETAHandler(calendar *holidays.Calendar) *ShipmentETAHandler {
+	return &ShipmentETAHandler{
+		validator: validator.New(),
+		calendar:  calendar,
+	}
+}
+
+// ServeHTTP handles HTTP requests for shipment ETAs
+func (h *ShipmentETAHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	pickup, err := time.Parse(holidays.DateLayout, query.Get("pickup"))
+	if err != nil {
+		http.Error(w, "Invalid pickup parameter, expected YYYY-MM-DD", http.StatusBadRequest)
+		return
+	}
+	transitDays, err := strconv.Atoi(query.Get("transitDays"))
+	if err != nil {
+		http.Error(w, "Invalid transitDays parameter", http.StatusBadRequest)
+		return
+	}
+
+	req := ShipmentETARequest{
+		Origin:      strings.ToUpper(query.Get("origin")),
+		Destination: strings.ToUpper(query.Get("destination")),
+		TransitDays: transitDays,
+	}
+	for _, country := range query["transit"] {
+		req.Transit = append(req.Transit, strings.ToUpper(country))
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		writeValidationError(w, err)
+		return
+	}
+
+	filter, err := parseFilter(query, append([]string{req.Origin, req.Destination}, req.Transit...))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	estimate, err := h.calendar.Filtered(filter).EstimateArrival(r.Context(), holidays.Shipment{
+		Origin:      req.Origin,
+		Destination: req.Destination,
+		Transit:     req.Transit,
+		Pickup:      pickup,
+		TransitDays: req.TransitDays,
+	})
+	if err != nil {
+		writeCalendarError(w, err)
+		return
+	}
+
+	unadjusted := pickup.AddDate(0, 0, req.TransitDays)
+	response := ShipmentETAResponse{
+		Origin:         req.Origin,
+		Destination:    req.Destination,
+		Transit:        req.Transit,
+		Pickup:         pickup.Format(holidays.DateLayout),
+		AdjustedPickup: estimate.Pickup.Format(holidays.DateLayout),
+		TransitDays:    req.TransitDays,
+		UnadjustedETA:  unadjusted.Format(holidays.DateLayout),
+		ETA:            estimate.Arrival.Format(holidays.DateLayout),
+		DelayDays:      int(estimate.Arrival.Sub(unadjusted).Hours() / 24),
+		Holidays:       estimate.Slips,
+	}
+	if response.Holidays == nil {
+		response.Holidays = []holidays.Slip{}
+	}
+
+	render.Write(w, r, http.StatusOK, response)
+}
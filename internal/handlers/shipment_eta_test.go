@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"kln-test/internal/holidays"
+)
+
+func TestShipmentETAHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		url        string
+		wantStatus int
+		wantETA    string
+		wantDelay  int
+		wantSlips  int
+	}{
+		{
+			name:       "holidays in transit",
+			url:        "/shipments/eta?origin=GB&destination=DE&transit=NL&pickup=2025-12-24&transitDays=3",
+			wantStatus: http.StatusOK,
+			wantETA:    "2025-12-29",
+			wantDelay:  2,
+			wantSlips:  2,
+		},
+		{
+			name:       "no holidays",
+			url:        "/shipments/eta?origin=de&destination=fr&pickup=2025-03-03&transitDays=2",
+			wantStatus: http.StatusOK,
+			wantETA:    "2025-03-05",
+			wantDelay:  0,
+			wantSlips:  0,
+		},
+		{
+			name:       "invalid pickup",
+			url:        "/shipments/eta?origin=GB&destination=DE&pickup=tomorrow&transitDays=3",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "missing transit days",
+			url:        "/shipments/eta?origin=GB&destination=DE&pickup=2025-12-24",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid transit country",
+			url:        "/shipments/eta?origin=GB&destination=DE&transit=NLD&pickup=2025-12-24&transitDays=3",
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "missing destination",
+			url:        "/shipments/eta?origin=GB&pickup=2025-12-24&transitDays=3",
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+	}
+
+	calendar := holidays.NewCalendar(holidays.NewService(holidays.NewRuleClient(holidays.DefaultRules)))
+	handler := NewShipmentETAHandler(calendar)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("Expected status code %d, got %d", tt.wantStatus, rec.Code)
+			}
+
+			if rec.Code == http.StatusOK {
+				var response ShipmentETAResponse
+				if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				if response.ETA != tt.wantETA || response.DelayDays != tt.wantDelay || len(response.Holidays) != tt.wantSlips {
+					t.Errorf("Expected ETA %s with %d days delay and %d holidays, got %+v", tt.wantETA, tt.wantDelay, tt.wantSlips, response)
+				}
+			}
+		})
+	}
+}
@@ -3,7 +3,10 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"os"
 	"time"
 
 	"kln-test/internal/config"
@@ -23,6 +26,7 @@ type SubscriptionRequest struct {
 type SubscriptionResponse struct {
 	Message string `json:"message"`
 	ID      string `json:"id"`
+	Status  string `json:"status,omitempty"`
 }
 
 // SubscriptionHandler handles shipping event subscriptions
@@ -30,15 +34,57 @@ type SubscriptionHandler struct {
 	validator *validator.Validate
 	pool      *worker.Pool[SubscriptionRequest]
 	cfg       *config.Config
+
+	// store is set when jobs are shared with other instances
+	store           *worker.FileStore[SubscriptionRequest]
+	stopCoordinator context.CancelFunc
+	coordinatorDone chan struct{}
 }
 
 // NewSubscriptionHandler creates a new subscription handler
-func NewSubscriptionHandler(cfg *config.Config) *SubscriptionHandler {
-	return &SubscriptionHandler{
+func NewSubscriptionHandler(cfg *config.Config) (*SubscriptionHandler, error) {
+	h := &SubscriptionHandler{
 		validator: validator.New(),
 		pool:      worker.NewPool[SubscriptionRequest](cfg),
 		cfg:       cfg,
 	}
+	h.pool.SetBatchProcessor(h.processSubscriptionBatch)
+
+	storeConfig := cfg.GetWorkerConfig().Store
+	if storeConfig.Dir == "" {
+		return h, nil
+	}
+
+	store, err := worker.NewFileStore[SubscriptionRequest](
+		storeConfig.Dir, instanceID(), time.Duration(storeConfig.LeaseTTL)*time.Second)
+	if err != nil {
+		return nil, err
+	}
+	h.store = store
+
+	coordinator, err := worker.NewCoordinator(store, h.pool, h.newJob,
+		time.Duration(storeConfig.PollInterval)*time.Second)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	h.stopCoordinator = cancel
+	h.coordinatorDone = make(chan struct{})
+	go func() {
+		defer close(h.coordinatorDone)
+		coordinator.Run(ctx)
+	}()
+
+	return h, nil
+}
+
+// instanceID identifies this process as a lease owner in the shared store
+func instanceID() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+	return fmt.Sprintf("%s-%d", host, os.Getpid())
 }
 
 // ServeHTTP handles HTTP requests for subscriptions
@@ -55,23 +101,34 @@ func (h *SubscriptionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 	}
 
 	if err := h.validator.Struct(req); err != nil {
-		w.WriteHeader(http.StatusUnprocessableEntity)
-		json.NewEncoder(w).Encode(map[string]string{
-			"error":   "Validation failed",
-			"details": err.Error(),
-		})
+		writeValidationError(w, err)
 		return
 	}
 
-	// Create a job for async processing
-	job := worker.Job[SubscriptionRequest]{
-		ID:      req.ConsumerID,
-		Payload: req,
-		Process: h.processSubscription,
+	var err error
+	if h.store != nil {
+		// Queue in the shared store so that any instance can pick the job up
+		err = h.store.Enqueue(req.ConsumerID, req)
+	} else {
+		err = h.pool.Submit(h.newJob(req.ConsumerID, req))
 	}
 
-	if err := h.pool.Submit(job); err != nil {
-		http.Error(w, "Server is busy, try again later", http.StatusServiceUnavailable)
+	if err != nil {
+		// A retried request for work we already have gets the original status
+		var duplicate *worker.DuplicateJobError
+		switch {
+		case errors.As(err, &duplicate):
+			h.writeDuplicate(w, req.ConsumerID, duplicate.Status)
+		case errors.Is(err, worker.ErrJobExists):
+			// Another instance may have claimed or finished the job since,
+			// so report what the store says now, or no status if unknown
+			status, _ := h.store.Status(req.ConsumerID)
+			h.writeDuplicate(w, req.ConsumerID, status)
+		case errors.Is(err, worker.ErrPoolClosed):
+			http.Error(w, "Server is shutting down, try again later", http.StatusServiceUnavailable)
+		default:
+			http.Error(w, "Server is busy, try again later", http.StatusServiceUnavailable)
+		}
 		return
 	}
 
@@ -79,9 +136,41 @@ func (h *SubscriptionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 	json.NewEncoder(w).Encode(SubscriptionResponse{
 		Message: "Subscription request accepted",
 		ID:      req.ConsumerID,
+		Status:  string(worker.StatusQueued),
+	})
+}
+
+// writeDuplicate responds to a subscription that is already being handled
+func (h *SubscriptionHandler) writeDuplicate(w http.ResponseWriter, id string, status worker.JobStatus) {
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(SubscriptionResponse{
+		Message: "Subscription request already accepted",
+		ID:      id,
+		Status:  string(status),
 	})
 }
 
+// newJob creates the pool job for a subscription
+func (h *SubscriptionHandler) newJob(id string, req SubscriptionRequest) worker.Job[SubscriptionRequest] {
+	return worker.Job[SubscriptionRequest]{
+		ID:       id,
+		Payload:  req,
+		Process:  h.processSubscription,
+		BatchKey: req.DeliveryURL,
+	}
+}
+
+// Shutdown stops accepting subscriptions and drains queued work until ctx
+// expires. Jobs claimed from a shared store that are not finished in time
+// are released for other instances.
+func (h *SubscriptionHandler) Shutdown(ctx context.Context) worker.DrainSummary {
+	if h.stopCoordinator != nil {
+		h.stopCoordinator()
+		<-h.coordinatorDone
+	}
+	return h.pool.Drain(ctx)
+}
+
 // processSubscription handles the subscription processing
 func (h *SubscriptionHandler) processSubscription(ctx context.Context, payload SubscriptionRequest) error {
 	// push the subscription to external service, e.g. cache DB, message queue, data lake, etc.
@@ -100,3 +189,11 @@ func (h *SubscriptionHandler) processSubscription(ctx context.Context, payload S
 	// })
 	return nil
 }
+
+// processSubscriptionBatch handles subscriptions sharing a delivery URL in one call
+func (h *SubscriptionHandler) processSubscriptionBatch(ctx context.Context, payloads []SubscriptionRequest) []error {
+	// push all subscriptions to the external service in a single request and
+	// map its per-item response back to an error slice, e.g. SQS SendMessageBatch
+	time.Sleep(5 * time.Second)
+	return nil
+}
@@ -0,0 +1,108 @@
+package holidays
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Aggregation modes combining the holidays of several countries
+const (
+	// ModeIntersect keeps dates that are holidays in every country
+	ModeIntersect = "intersect"
+	// ModeUnion keeps dates that are a holiday in any country
+	ModeUnion = "union"
+	// ModeDifference keeps dates that are holidays in the first country
+	// but in none of the others
+	ModeDifference = "difference"
+)
+
+// DateEntry lists the countries that are off on a date
+type DateEntry struct {
+	Date      string    `json:"date"`
+	Countries []string  `json:"countries"`
+	Holidays  []Holiday `json:"holidays"`
+}
+
+// ErrIncompleteResults is returned by Aggregate when a country's lookup
+// failed. Combining the others would give a wrong answer, such as dates
+// that only look shared because the failed country is missing.
+var ErrIncompleteResults = errors.New("cannot combine countries whose lookup failed")
+
+// Aggregate combines per-country results into a calendar keyed by date,
+// sorted by date. It fails if any country's lookup failed, and an unknown
+// mode yields nil.
+func Aggregate(mode string, results []CountryResult) ([]DateEntry, error) {
+	var failed []string
+	for _, result := range results {
+		if result.Error != "" {
+			failed = append(failed, result.CountryCode)
+		}
+	}
+	if len(failed) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrIncompleteResults, strings.Join(failed, ", "))
+	}
+
+	var (
+		byDate    = make(map[string]*DateEntry)
+		countries []string
+		seen      = make(map[string]bool)
+	)
+	for _, result := range results {
+		if seen[result.CountryCode] {
+			continue
+		}
+		seen[result.CountryCode] = true
+		countries = append(countries, result.CountryCode)
+
+		for _, holiday := range result.Holidays {
+			entry, ok := byDate[holiday.Date]
+			if !ok {
+				entry = &DateEntry{Date: holiday.Date}
+				byDate[holiday.Date] = entry
+			}
+			if !contains(entry.Countries, result.CountryCode) {
+				entry.Countries = append(entry.Countries, result.CountryCode)
+			}
+			entry.Holidays = append(entry.Holidays, holiday)
+		}
+	}
+	if len(countries) == 0 {
+		return []DateEntry{}, nil
+	}
+
+	var keep func(entry *DateEntry) bool
+	switch mode {
+	case ModeIntersect:
+		keep = func(entry *DateEntry) bool { return len(entry.Countries) == len(countries) }
+	case ModeUnion:
+		keep = func(entry *DateEntry) bool { return true }
+	case ModeDifference:
+		keep = func(entry *DateEntry) bool {
+			return len(entry.Countries) == 1 && entry.Countries[0] == countries[0]
+		}
+	default:
+		return nil, nil
+	}
+
+	entries := make([]DateEntry, 0, len(byDate))
+	for _, entry := range byDate {
+		if keep(entry) {
+			entries = append(entries, *entry)
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Date < entries[j].Date
+	})
+	return entries, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
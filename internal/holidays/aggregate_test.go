@@ -0,0 +1,74 @@
+package holidays
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestAggregate(t *testing.T) {
+	results := []CountryResult{
+		{CountryCode: "DE", Holidays: []Holiday{
+			{Date: "2025-01-01", Name: "New Year's Day", CountryCode: "DE"},
+			{Date: "2025-10-03", Name: "German Unity Day", CountryCode: "DE"},
+			{Date: "2025-12-25", Name: "Christmas Day", CountryCode: "DE"},
+		}},
+		{CountryCode: "US", Holidays: []Holiday{
+			{Date: "2025-01-01", Name: "New Year's Day", CountryCode: "US"},
+			{Date: "2025-07-04", Name: "Independence Day", CountryCode: "US"},
+			{Date: "2025-12-25", Name: "Christmas Day", CountryCode: "US"},
+		}},
+		{CountryCode: "FR", Holidays: []Holiday{
+			{Date: "2025-01-01", Name: "New Year's Day", CountryCode: "FR"},
+			{Date: "2025-07-14", Name: "Bastille Day", CountryCode: "FR"},
+		}},
+	}
+
+	tests := []struct {
+		mode  string
+		dates []string
+	}{
+		{ModeIntersect, []string{"2025-01-01"}},
+		{ModeUnion, []string{"2025-01-01", "2025-07-04", "2025-07-14", "2025-10-03", "2025-12-25"}},
+		{ModeDifference, []string{"2025-10-03"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			var dates []string
+			entries, err := Aggregate(tt.mode, results)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			for _, entry := range entries {
+				dates = append(dates, entry.Date)
+			}
+			if !reflect.DeepEqual(dates, tt.dates) {
+				t.Errorf("Expected dates %v, got %v", tt.dates, dates)
+			}
+		})
+	}
+
+	union, _ := Aggregate(ModeUnion, results)
+	if countries := union[len(union)-1].Countries; !reflect.DeepEqual(countries, []string{"DE", "US"}) {
+		t.Errorf("Expected DE and US to be off on Christmas Day, got %v", countries)
+	}
+	if entries, _ := Aggregate("bogus", results); entries != nil {
+		t.Errorf("Expected no entries for an unknown mode, got %v", entries)
+	}
+}
+
+func TestAggregateFailsWithFailedCountry(t *testing.T) {
+	results := []CountryResult{
+		{CountryCode: "DE", Holidays: []Holiday{{Date: "2025-10-03", Name: "German Unity Day", CountryCode: "DE"}}},
+		{CountryCode: "XX", Error: "unknown country"},
+	}
+
+	entries, err := Aggregate(ModeIntersect, results)
+	if !errors.Is(err, ErrIncompleteResults) {
+		t.Fatalf("Expected ErrIncompleteResults, got %v", err)
+	}
+	if entries != nil {
+		t.Errorf("Expected no entries, got %v", entries)
+	}
+}
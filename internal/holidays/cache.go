@@ -0,0 +1,160 @@
+package holidays
+
+import (
+	"container/list"
+	"context"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// CacheOptions configures a CachingClient
+type CacheOptions struct {
+	// TTL is how long a successful lookup is served from the cache
+	TTL time.Duration
+	// ErrorTTL is how long a failed lookup is remembered; zero disables
+	// negative caching
+	ErrorTTL time.Duration
+	// MaxEntries bounds the cache, evicting the least recently used entry;
+	// zero means unbounded
+	MaxEntries int
+}
+
+// CacheStats reports how effective the cache has been
+type CacheStats struct {
+	Hits    int64 `json:"hits"`
+	Misses  int64 `json:"misses"`
+	Entries int   `json:"entries"`
+}
+
+// CachingClient decorates a Client with an in-memory LRU cache keyed by
+// year and country
+type CachingClient struct {
+	next Client
+	opts CacheOptions
+
+	mu      sync.Mutex
+	entries map[cacheKey]*list.Element
+	lru     *list.List
+
+	hits   atomic.Int64
+	misses atomic.Int64
+}
+
+type cacheKey struct {
+	year    int
+	country string
+}
+
+type cacheEntry struct {
+	key      cacheKey
+	holidays []Holiday
+	err      error
+	source   Source
+	expires  time.Time
+}
+
+// NewCachingClient creates a caching decorator around next
+func NewCachingClient(next Client, opts CacheOptions) *CachingClient {
+	return &CachingClient{
+		next:    next,
+		opts:    opts,
+		entries: make(map[cacheKey]*list.Element),
+		lru:     list.New(),
+	}
+}
+
+// GetHolidays returns cached holidays if present, otherwise fetches and caches them
+func (c *CachingClient) GetHolidays(ctx context.Context, year int, countryCode string) ([]Holiday, error) {
+	key := cacheKey{year: year, country: strings.ToUpper(countryCode)}
+
+	if entry, ok := c.lookup(key); ok {
+		c.hits.Add(1)
+		setSource(ctx, entry.source)
+		return copyHolidays(entry.holidays), entry.err
+	}
+	c.misses.Add(1)
+
+	innerCtx, src := WithSource(ctx)
+	holidays, err := c.next.GetHolidays(innerCtx, year, countryCode)
+	setSource(ctx, *src)
+
+	// A cancelled caller says nothing about the upstream, so don't remember it
+	if err != nil && ctx.Err() != nil {
+		return nil, err
+	}
+	c.store(key, holidays, err, *src)
+	return copyHolidays(holidays), err
+}
+
+// Stats returns the hit and miss counters and the current number of entries
+func (c *CachingClient) Stats() CacheStats {
+	c.mu.Lock()
+	entries := c.lru.Len()
+	c.mu.Unlock()
+
+	return CacheStats{
+		Hits:    c.hits.Load(),
+		Misses:  c.misses.Load(),
+		Entries: entries,
+	}
+}
+
+// lookup returns a live entry and marks it as recently used
+func (c *CachingClient) lookup(key cacheKey) (cacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return cacheEntry{}, false
+	}
+
+	entry := elem.Value.(*cacheEntry)
+	if time.Now().After(entry.expires) {
+		c.lru.Remove(elem)
+		delete(c.entries, key)
+		return cacheEntry{}, false
+	}
+
+	c.lru.MoveToFront(elem)
+	return *entry, true
+}
+
+// store adds or replaces an entry, evicting the least recently used if full.
+// Errors and stale data are kept only for ErrorTTL so upstream is retried soon.
+func (c *CachingClient) store(key cacheKey, holidays []Holiday, err error, src Source) {
+	ttl := c.opts.TTL
+	if err != nil || src.Stale {
+		ttl = c.opts.ErrorTTL
+	}
+	if ttl <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := &cacheEntry{key: key, holidays: holidays, err: err, source: src, expires: time.Now().Add(ttl)}
+	if elem, ok := c.entries[key]; ok {
+		elem.Value = entry
+		c.lru.MoveToFront(elem)
+		return
+	}
+
+	c.entries[key] = c.lru.PushFront(entry)
+	for c.opts.MaxEntries > 0 && c.lru.Len() > c.opts.MaxEntries {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+	}
+}
+
+// copyHolidays protects cached data from modification by callers
+func copyHolidays(holidays []Holiday) []Holiday {
+	if holidays == nil {
+		return nil
+	}
+	return append([]Holiday(nil), holidays...)
+}
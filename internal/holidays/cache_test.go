@@ -0,0 +1,87 @@
+package holidays
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type countingClient struct {
+	calls int
+	err   error
+}
+
+func (c *countingClient) GetHolidays(ctx context.Context, year int, countryCode string) ([]Holiday, error) {
+	c.calls++
+	if c.err != nil {
+		return nil, c.err
+	}
+	return []Holiday{{Date: "2025-01-01", Name: "New Year's Day", CountryCode: countryCode}}, nil
+}
+
+func TestCachingClientHitsAndMisses(t *testing.T) {
+	upstream := &countingClient{}
+	client := NewCachingClient(upstream, CacheOptions{TTL: time.Minute})
+
+	for i := 0; i < 3; i++ {
+		if _, err := client.GetHolidays(context.Background(), 2025, "DE"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	// Country codes are case-insensitive
+	client.GetHolidays(context.Background(), 2025, "de")
+
+	if upstream.calls != 1 {
+		t.Errorf("Expected 1 upstream call, got %d", upstream.calls)
+	}
+	stats := client.Stats()
+	if stats.Hits != 3 || stats.Misses != 1 || stats.Entries != 1 {
+		t.Errorf("Unexpected stats: %+v", stats)
+	}
+}
+
+func TestCachingClientEvictsLeastRecentlyUsed(t *testing.T) {
+	upstream := &countingClient{}
+	client := NewCachingClient(upstream, CacheOptions{TTL: time.Minute, MaxEntries: 2})
+	ctx := context.Background()
+
+	client.GetHolidays(ctx, 2025, "CA")
+	client.GetHolidays(ctx, 2025, "DE")
+	client.GetHolidays(ctx, 2025, "CA") // CA is now most recently used
+	client.GetHolidays(ctx, 2025, "FR") // evicts DE
+
+	client.GetHolidays(ctx, 2025, "CA")
+	if upstream.calls != 3 {
+		t.Errorf("Expected CA to stay cached, got %d upstream calls", upstream.calls)
+	}
+
+	client.GetHolidays(ctx, 2025, "DE")
+	if upstream.calls != 4 {
+		t.Errorf("Expected DE to be evicted, got %d upstream calls", upstream.calls)
+	}
+}
+
+func TestCachingClientNegativeCaching(t *testing.T) {
+	upstream := &countingClient{err: errors.New("upstream down")}
+	client := NewCachingClient(upstream, CacheOptions{TTL: time.Minute, ErrorTTL: 20 * time.Millisecond})
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetHolidays(ctx, 2025, "DE"); err == nil {
+			t.Fatal("Expected cached error")
+		}
+	}
+	if upstream.calls != 1 {
+		t.Errorf("Expected the error to be cached, got %d upstream calls", upstream.calls)
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	upstream.err = nil
+	if _, err := client.GetHolidays(ctx, 2025, "DE"); err != nil {
+		t.Errorf("Expected error to expire, got %v", err)
+	}
+	if upstream.calls != 2 {
+		t.Errorf("Expected a fresh upstream call, got %d", upstream.calls)
+	}
+}
@@ -0,0 +1,260 @@
+package holidays
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DateLayout is the format of holiday dates
+const DateLayout = "2006-01-02"
+
+// ErrNoBusinessDays is returned when a weekend definition leaves no
+// working days in the week
+var ErrNoBusinessDays = errors.New("weekend covers the whole week")
+
+// defaultWeekend applies to countries without their own weekend definition
+var defaultWeekend = []time.Weekday{time.Saturday, time.Sunday}
+
+// Calendar answers business-day questions for a country, treating its
+// weekend and nationwide public holidays as non-working days. A filtered
+// calendar also treats the regional holidays of its subdivisions as days off.
+type Calendar struct {
+	service  Service
+	weekends map[string][]time.Weekday
+	filter   Filter
+}
+
+// CalendarOption customises a Calendar
+type CalendarOption func(*Calendar)
+
+// WithWeekends sets the weekend days of countries, keyed by country code.
+// Countries that are not listed have a Saturday and Sunday weekend.
+func WithWeekends(weekends map[string][]time.Weekday) CalendarOption {
+	return func(c *Calendar) {
+		for country, days := range weekends {
+			c.weekends[strings.ToUpper(country)] = days
+		}
+	}
+}
+
+// NewCalendar creates a calendar looking holidays up through service
+func NewCalendar(service Service, opts ...CalendarOption) *Calendar {
+	c := &Calendar{service: service, weekends: make(map[string][]time.Weekday)}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Filtered returns a view of the calendar that applies filter to every
+// lookup. Subdivisions in the filter are checked against the holiday data.
+func (c *Calendar) Filtered(filter Filter) *Calendar {
+	filtered := *c
+	filtered.filter = filter
+	return &filtered
+}
+
+// AddBusinessDays returns the date n business days after start, or before
+// it when n is negative. The start date itself is never counted.
+func (c *Calendar) AddBusinessDays(ctx context.Context, country string, start time.Time, n int) (time.Time, error) {
+	days, err := c.days(country)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	step := 1
+	if n < 0 {
+		step, n = -1, -n
+	}
+	date := truncateDay(start)
+	for n > 0 {
+		date = date.AddDate(0, 0, step)
+		business, err := days.isBusinessDay(ctx, date)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if business {
+			n--
+		}
+	}
+	return date, nil
+}
+
+// CountBusinessDays counts the business days after from up to and including
+// to, so that AddBusinessDays(from, n) is the date whose count is n. The
+// count is negative when to is before from.
+func (c *Calendar) CountBusinessDays(ctx context.Context, country string, from, to time.Time) (int, error) {
+	days, err := c.days(country)
+	if err != nil {
+		return 0, err
+	}
+
+	from, to = truncateDay(from), truncateDay(to)
+	sign := 1
+	if to.Before(from) {
+		// Count the same span forwards so the two directions agree
+		sign, from, to = -1, to.AddDate(0, 0, -1), from.AddDate(0, 0, -1)
+	}
+
+	count := 0
+	for date := from.AddDate(0, 0, 1); !date.After(to); date = date.AddDate(0, 0, 1) {
+		business, err := days.isBusinessDay(ctx, date)
+		if err != nil {
+			return 0, err
+		}
+		if business {
+			count++
+		}
+	}
+	return sign * count, nil
+}
+
+// IsBusinessDay reports whether date is neither a weekend day nor a
+// nationwide public holiday in country
+func (c *Calendar) IsBusinessDay(ctx context.Context, country string, date time.Time) (bool, error) {
+	days, err := c.days(country)
+	if err != nil {
+		return false, err
+	}
+	return days.isBusinessDay(ctx, truncateDay(date))
+}
+
+// HolidaysOn returns the nationwide holidays in country on date
+func (c *Calendar) HolidaysOn(ctx context.Context, country string, date time.Time) ([]Holiday, error) {
+	days, err := c.days(country)
+	if err != nil {
+		return nil, err
+	}
+	return days.holidaysOn(ctx, truncateDay(date))
+}
+
+// maxLookaheadYears bounds how far NextHolidays searches for holidays
+const maxLookaheadYears = 3
+
+// NextHolidays returns up to n nationwide holidays in country on or after
+// from, searching at most a few years ahead
+func (c *Calendar) NextHolidays(ctx context.Context, country string, from time.Time, n int) ([]Holiday, error) {
+	days, err := c.days(country)
+	if err != nil {
+		return nil, err
+	}
+
+	first := from.Format(DateLayout)
+	next := make([]Holiday, 0, n)
+	for year := from.Year(); year <= from.Year()+maxLookaheadYears && len(next) < n; year++ {
+		loaded, err := days.year(ctx, year)
+		if err != nil {
+			return nil, err
+		}
+		for _, holiday := range loaded.holidays {
+			if holiday.Date >= first && len(next) < n {
+				next = append(next, holiday)
+			}
+		}
+	}
+	return next, nil
+}
+
+// Weekend returns the weekend days of country
+func (c *Calendar) Weekend(country string) []time.Weekday {
+	if days, ok := c.weekends[strings.ToUpper(country)]; ok {
+		return days
+	}
+	return defaultWeekend
+}
+
+// days starts a lookup of business days in country. Holidays are fetched
+// one year at a time as the lookup reaches it.
+func (c *Calendar) days(country string) (*businessDays, error) {
+	weekend := make(map[time.Weekday]bool)
+	for _, day := range c.Weekend(country) {
+		weekend[day] = true
+	}
+	if len(weekend) >= 7 {
+		return nil, fmt.Errorf("%s: %w", country, ErrNoBusinessDays)
+	}
+	return &businessDays{
+		calendar: c,
+		country:  strings.ToUpper(country),
+		weekend:  weekend,
+		years:    make(map[int]*yearHolidays),
+	}, nil
+}
+
+// businessDays caches the holidays of one country for a single lookup
+type businessDays struct {
+	calendar *Calendar
+	country  string
+	weekend  map[time.Weekday]bool
+	years    map[int]*yearHolidays
+}
+
+// yearHolidays holds the holidays of a country that apply in one year
+type yearHolidays struct {
+	// holidays is sorted by date
+	holidays []Holiday
+	byDate   map[string][]Holiday
+}
+
+func (d *businessDays) isBusinessDay(ctx context.Context, date time.Time) (bool, error) {
+	if d.weekend[date.Weekday()] {
+		return false, nil
+	}
+	holidays, err := d.holidaysOn(ctx, date)
+	return len(holidays) == 0, err
+}
+
+// holidaysOn returns the holidays on date
+func (d *businessDays) holidaysOn(ctx context.Context, date time.Time) ([]Holiday, error) {
+	year, err := d.year(ctx, date.Year())
+	if err != nil {
+		return nil, err
+	}
+	return year.byDate[date.Format(DateLayout)], nil
+}
+
+// year returns the country's holidays in year that apply to the filter's
+// subdivisions
+func (d *businessDays) year(ctx context.Context, year int) (*yearHolidays, error) {
+	if cached, ok := d.years[year]; ok {
+		return cached, nil
+	}
+
+	results := d.calendar.service.GetHolidaysForCountries(ctx, year, []string{d.country})
+	if len(results) != 1 {
+		return nil, fmt.Errorf("no holiday result for %s %d", d.country, year)
+	}
+	if results[0].Err != nil {
+		return nil, fmt.Errorf("failed to get holidays for %s %d: %w", d.country, year, results[0].Err)
+	}
+	if results[0].Error != "" {
+		return nil, fmt.Errorf("failed to get holidays for %s %d: %s", d.country, year, results[0].Error)
+	}
+
+	filter := d.calendar.filter
+	if err := filter.Validate(d.country, results[0].Holidays); err != nil {
+		return nil, err
+	}
+
+	loaded := &yearHolidays{byDate: make(map[string][]Holiday)}
+	for _, holiday := range results[0].Holidays {
+		if (holiday.Global || filter.inSubdivision(d.country, holiday)) && filter.allowsType(holiday) {
+			loaded.holidays = append(loaded.holidays, holiday)
+			loaded.byDate[holiday.Date] = append(loaded.byDate[holiday.Date], holiday)
+		}
+	}
+	sort.SliceStable(loaded.holidays, func(i, j int) bool {
+		return loaded.holidays[i].Date < loaded.holidays[j].Date
+	})
+	d.years[year] = loaded
+	return loaded, nil
+}
+
+// truncateDay drops the time of day, keeping the calendar date
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,188 @@
+package holidays
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func date(value string) time.Time {
+	t, err := time.Parse(DateLayout, value)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestCalendarAddBusinessDays(t *testing.T) {
+	calendar := NewCalendar(NewService(NewRuleClient(DefaultRules)), WithWeekends(map[string][]time.Weekday{
+		"FR": {time.Friday, time.Saturday},
+	}))
+
+	tests := []struct {
+		name    string
+		country string
+		start   string
+		days    int
+		want    string
+	}{
+		{"across year end", "DE", "2025-12-23", 5, "2026-01-02"},
+		{"backwards across year end", "DE", "2026-01-02", -5, "2025-12-23"},
+		{"from a weekend", "DE", "2025-06-07", 1, "2025-06-10"}, // Whit Monday is a holiday
+		{"zero days", "DE", "2025-06-07", 0, "2025-06-07"},
+		{"custom weekend", "FR", "2025-07-10", 1, "2025-07-13"}, // Friday and Saturday off
+		{"regional holidays ignored", "GB", "2025-08-22", 1, "2025-08-25"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := calendar.AddBusinessDays(context.Background(), tt.country, date(tt.start), tt.days)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got.Format(DateLayout) != tt.want {
+				t.Errorf("Expected %s, got %s", tt.want, got.Format(DateLayout))
+			}
+		})
+	}
+}
+
+func TestCalendarCountBusinessDays(t *testing.T) {
+	calendar := NewCalendar(NewService(NewRuleClient(DefaultRules)))
+
+	tests := []struct {
+		from string
+		to   string
+		want int
+	}{
+		{"2025-12-23", "2026-01-02", 5},
+		{"2026-01-02", "2025-12-23", -5},
+		{"2025-12-23", "2025-12-23", 0},
+		{"2025-01-01", "2025-12-31", 252},
+	}
+
+	for _, tt := range tests {
+		got, err := calendar.CountBusinessDays(context.Background(), "DE", date(tt.from), date(tt.to))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if got != tt.want {
+			t.Errorf("Expected %d business days from %s to %s, got %d", tt.want, tt.from, tt.to, got)
+		}
+	}
+}
+
+func TestCalendarErrors(t *testing.T) {
+	calendar := NewCalendar(NewService(&mockClient{err: errors.New("upstream down")}), WithWeekends(map[string][]time.Weekday{
+		"XX": {time.Sunday, time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday},
+	}))
+
+	if _, err := calendar.AddBusinessDays(context.Background(), "DE", date("2025-01-01"), 1); err == nil {
+		t.Error("Expected an error when holidays cannot be fetched")
+	}
+	if _, err := calendar.AddBusinessDays(context.Background(), "XX", date("2025-01-01"), 1); !errors.Is(err, ErrNoBusinessDays) {
+		t.Errorf("Expected ErrNoBusinessDays, got %v", err)
+	}
+}
+
+func TestCalendarEstimateArrival(t *testing.T) {
+	calendar := NewCalendar(NewService(NewRuleClient(DefaultRules)))
+
+	tests := []struct {
+		name     string
+		shipment Shipment
+		pickup   string
+		arrival  string
+		slips    []string
+	}{
+		{
+			name:     "held in transit",
+			shipment: Shipment{Origin: "GB", Destination: "DE", Transit: []string{"NL"}, Pickup: date("2025-12-24"), TransitDays: 3},
+			pickup:   "2025-12-24",
+			arrival:  "2025-12-29",
+			slips:    []string{"transit NL 2025-12-25", "transit NL 2025-12-26"},
+		},
+		{
+			name:     "pickup and delivery holidays",
+			shipment: Shipment{Origin: "GB", Destination: "DE", Pickup: date("2025-12-25"), TransitDays: 3},
+			pickup:   "2025-12-29",
+			arrival:  "2026-01-02",
+			slips:    []string{"pickup GB 2025-12-25", "pickup GB 2025-12-26", "delivery DE 2026-01-01"},
+		},
+		{
+			name:     "weekend delivery",
+			shipment: Shipment{Origin: "DE", Destination: "FR", Pickup: date("2025-07-10"), TransitDays: 2},
+			pickup:   "2025-07-10",
+			arrival:  "2025-07-15", // Monday 14th is Bastille Day
+			slips:    []string{"delivery FR 2025-07-14"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			estimate, err := calendar.EstimateArrival(context.Background(), tt.shipment)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got := estimate.Pickup.Format(DateLayout); got != tt.pickup {
+				t.Errorf("Expected pickup %s, got %s", tt.pickup, got)
+			}
+			if got := estimate.Arrival.Format(DateLayout); got != tt.arrival {
+				t.Errorf("Expected arrival %s, got %s", tt.arrival, got)
+			}
+			var slips []string
+			for _, slip := range estimate.Slips {
+				slips = append(slips, slip.Stage+" "+slip.CountryCode+" "+slip.Date)
+			}
+			if strings.Join(slips, ", ") != strings.Join(tt.slips, ", ") {
+				t.Errorf("Expected slips %v, got %v", tt.slips, slips)
+			}
+		})
+	}
+}
+
+func TestCalendarLongWeekends(t *testing.T) {
+	calendar := NewCalendar(NewService(NewRuleClient(DefaultRules)))
+
+	weekends, err := calendar.LongWeekends(context.Background(), "DE", 2025)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	want := []string{
+		"2025-04-18..2025-04-21 (4)",
+		"2025-05-01..2025-05-04 (4) bridge 2025-05-02",
+		"2025-05-29..2025-06-01 (4) bridge 2025-05-30",
+		"2025-06-07..2025-06-09 (3)",
+		"2025-10-03..2025-10-05 (3)",
+		"2025-12-25..2025-12-28 (4)",
+	}
+	var got []string
+	for _, weekend := range weekends {
+		summary := fmt.Sprintf("%s..%s (%d)", weekend.StartDate, weekend.EndDate, weekend.DayCount)
+		if weekend.NeedBridgeDay {
+			summary += " bridge " + strings.Join(weekend.BridgeDays, ",")
+		}
+		got = append(got, summary)
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Expected long weekends\n%s\ngot\n%s", strings.Join(want, "\n"), strings.Join(got, "\n"))
+	}
+}
+
+func TestCalendarLongWeekendsBridgeBeforeHoliday(t *testing.T) {
+	calendar := NewCalendar(NewService(NewRuleClient(DefaultRules)))
+
+	// Christmas 2029 is on a Tuesday, so Monday the 24th bridges it to the weekend
+	weekends, err := calendar.LongWeekends(context.Background(), "DE", 2029)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	last := weekends[len(weekends)-1]
+	if last.StartDate != "2029-12-22" || last.EndDate != "2029-12-26" || !last.NeedBridgeDay {
+		t.Errorf("Expected a bridged Christmas break from 2029-12-22 to 2029-12-26, got %+v", last)
+	}
+}
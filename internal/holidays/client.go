@@ -3,8 +3,11 @@ package holidays
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log"
 	"net/http"
+	"sync"
 	"time"
 )
 
@@ -15,42 +18,121 @@ type Client interface {
 
 // HTTPClient implements the Client interface using the Nager.Date API
 type HTTPClient struct {
+	mu         sync.RWMutex
 	baseURL    string
 	httpClient *http.Client
+	userAgent  string
+	retry      RetryPolicy
+
+	// settings is checked before every lookup when the client follows
+	// configuration, and applied is what the client was last built from
+	settings func() ClientSettings
+	applied  ClientSettings
+}
+
+// upstream is a consistent snapshot of the client's connection settings
+type upstream struct {
+	baseURL    string
+	httpClient *http.Client
+	userAgent  string
+	retry      RetryPolicy
 }
 
 // NewClient creates a new holiday API client
 func NewClient() *HTTPClient {
 	return &HTTPClient{
-		baseURL: "https://date.nager.at/api/v3",
+		baseURL: DefaultBaseURL,
 		httpClient: &http.Client{
-			Timeout: 10 * time.Second,
+			Timeout: DefaultTimeout,
 		},
+		retry: DefaultRetryPolicy,
+	}
+}
+
+// SetRetryPolicy replaces the policy used for transient failures
+func (c *HTTPClient) SetRetryPolicy(policy RetryPolicy) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.retry = policy
+}
+
+func (c *HTTPClient) snapshot() upstream {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return upstream{
+		baseURL:    c.baseURL,
+		httpClient: c.httpClient,
+		userAgent:  c.userAgent,
+		retry:      c.retry,
 	}
 }
 
-// GetHolidays fetches holidays for a specific country and year
+// GetHolidays fetches holidays for a specific country and year, retrying
+// timeouts, network errors and 5xx/429 responses with exponential backoff.
+// Permanent errors and malformed bodies are returned straight away.
 func (c *HTTPClient) GetHolidays(ctx context.Context, year int, countryCode string) ([]Holiday, error) {
-	url := fmt.Sprintf("%s/PublicHolidays/%d/%s", c.baseURL, year, countryCode)
+	c.refresh()
+	conn := c.snapshot()
+
+	var (
+		holidays []Holiday
+		err      error
+	)
+
+	for attempt := 1; ; attempt++ {
+		holidays, err = c.fetch(ctx, conn, year, countryCode)
+		if err == nil || IsPermanent(err) || errors.Is(err, ErrMalformedResponse) ||
+			ctx.Err() != nil || attempt >= conn.retry.MaxAttempts {
+			return holidays, err
+		}
+
+		wait := conn.retry.backoff(attempt)
+		var statusErr *StatusError
+		if errors.As(err, &statusErr) && statusErr.RetryAfter > 0 {
+			// The server told us when to come back; don't retry if that's too far away
+			if statusErr.RetryAfter > conn.retry.MaxBackoff {
+				return nil, err
+			}
+			wait = statusErr.RetryAfter
+		}
+
+		log.Printf("Retrying holidays for %s %d in %s after attempt %d failed: %v", countryCode, year, wait, attempt, err)
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return nil, err
+		}
+	}
+}
+
+// fetch makes a single request to the API
+func (c *HTTPClient) fetch(ctx context.Context, conn upstream, year int, countryCode string) ([]Holiday, error) {
+	url := fmt.Sprintf("%s/PublicHolidays/%d/%s", conn.baseURL, year, countryCode)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
+	if conn.userAgent != "" {
+		req.Header.Set("User-Agent", conn.userAgent)
+	}
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := conn.httpClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch holidays: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API returned status %d", resp.StatusCode)
+		return nil, &StatusError{
+			StatusCode: resp.StatusCode,
+			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+		}
 	}
 
 	var holidays []Holiday
 	if err := json.NewDecoder(resp.Body).Decode(&holidays); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+		return nil, fmt.Errorf("%w: %v", ErrMalformedResponse, err)
 	}
 
 	return holidays, nil
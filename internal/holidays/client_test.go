@@ -3,9 +3,12 @@ package holidays
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestHTTPClient(t *testing.T) {
@@ -36,3 +39,167 @@ func TestHTTPClient(t *testing.T) {
 		t.Errorf("Expected %d holidays, got %d", len(testHolidays), len(holidays))
 	}
 }
+
+func TestHTTPClientDecodesTypes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Nager.Date v3 lists the types under "types"; older copies used "type"
+		w.Write([]byte(`[
+			{"date": "2025-08-25", "name": "Summer Bank Holiday", "countryCode": "GB", "types": ["Bank"]},
+			{"date": "2025-12-26", "name": "Boxing Day", "countryCode": "GB", "type": ["Public", "Bank"]}
+		]`))
+	}))
+	defer server.Close()
+
+	client := NewClient()
+	client.baseURL = server.URL
+
+	holidays, err := client.GetHolidays(context.Background(), 2025, "GB")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(holidays) != 2 {
+		t.Fatalf("Expected 2 holidays, got %d", len(holidays))
+	}
+	if got := strings.Join(holidays[0].Types, ","); got != "Bank" {
+		t.Errorf("Expected types Bank, got %q", got)
+	}
+	if got := strings.Join(holidays[1].Types, ","); got != "Public,Bank" {
+		t.Errorf("Expected types Public,Bank from the legacy field, got %q", got)
+	}
+}
+
+func TestHTTPClientRetriesTransientFailures(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			http.Error(w, "Unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		json.NewEncoder(w).Encode([]Holiday{{Date: "2025-01-01", CountryCode: "DE"}})
+	}))
+	defer server.Close()
+
+	client := NewClient()
+	client.baseURL = server.URL
+	client.SetRetryPolicy(RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 10 * time.Millisecond})
+
+	holidays, err := client.GetHolidays(context.Background(), 2025, "DE")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(holidays) != 1 || calls != 3 {
+		t.Errorf("Expected success on the third attempt, got %d holidays after %d calls", len(holidays), calls)
+	}
+}
+
+func TestHTTPClientDoesNotRetryPermanentErrors(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		http.Error(w, "Not found", http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := NewClient()
+	client.baseURL = server.URL
+	client.SetRetryPolicy(RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 10 * time.Millisecond})
+
+	_, err := client.GetHolidays(context.Background(), 2025, "XX")
+	if !IsPermanent(err) {
+		t.Errorf("Expected a permanent error, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("Expected 1 call, got %d", calls)
+	}
+}
+
+func TestHTTPClientHonorsRetryAfter(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("Retry-After", "1")
+			http.Error(w, "Slow down", http.StatusTooManyRequests)
+			return
+		}
+		json.NewEncoder(w).Encode([]Holiday{})
+	}))
+	defer server.Close()
+
+	client := NewClient()
+	client.baseURL = server.URL
+
+	client.SetRetryPolicy(RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond, MaxBackoff: 100 * time.Millisecond})
+	if _, err := client.GetHolidays(context.Background(), 2025, "DE"); err == nil {
+		t.Error("Expected an error when Retry-After exceeds the maximum backoff")
+	}
+
+	calls = 0
+	client.SetRetryPolicy(RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond, MaxBackoff: 2 * time.Second})
+	start := time.Now()
+	if _, err := client.GetHolidays(context.Background(), 2025, "DE"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("Expected to wait for Retry-After, waited %s", elapsed)
+	}
+}
+
+func TestConfiguredClientFollowsSettings(t *testing.T) {
+	var userAgents []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgents = append(userAgents, r.UserAgent())
+		json.NewEncoder(w).Encode([]Holiday{})
+	}))
+	defer server.Close()
+
+	settings := ClientSettings{BaseURL: server.URL, UserAgent: "first"}
+	client, err := NewConfiguredClient(func() ClientSettings { return settings })
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if _, err := client.GetHolidays(context.Background(), 2025, "DE"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	settings.UserAgent = "second"
+	if _, err := client.GetHolidays(context.Background(), 2025, "DE"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Invalid settings are ignored in favour of the last good ones
+	settings.ProxyURL = "://bad"
+	if _, err := client.GetHolidays(context.Background(), 2025, "DE"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	want := []string{"first", "second", "second"}
+	for i := range want {
+		if i >= len(userAgents) || userAgents[i] != want[i] {
+			t.Fatalf("Expected user agents %v, got %v", want, userAgents)
+		}
+	}
+}
+
+func TestHTTPClientDoesNotRetryMalformedResponses(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Write([]byte(`{"not": "a list"`))
+	}))
+	defer server.Close()
+
+	client := NewClient()
+	client.baseURL = server.URL
+	client.SetRetryPolicy(RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 10 * time.Millisecond})
+
+	_, err := client.GetHolidays(context.Background(), 2025, "DE")
+	if !errors.Is(err, ErrMalformedResponse) {
+		t.Errorf("Expected ErrMalformedResponse, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("Expected 1 call, got %d", calls)
+	}
+}
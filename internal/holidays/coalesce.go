@@ -0,0 +1,97 @@
+package holidays
+
+import (
+	"context"
+	"strings"
+	"sync"
+)
+
+// CoalescingClient decorates a Client so that concurrent lookups for the
+// same year and country share a single upstream call.
+//
+// The shared call is detached from the context of the caller that started
+// it, so cancelling that caller does not fail the others. It is only
+// cancelled once every caller waiting on it has given up.
+type CoalescingClient struct {
+	next Client
+
+	mu      sync.Mutex
+	flights map[cacheKey]*flight
+}
+
+// flight is an upstream call shared by one or more waiting callers
+type flight struct {
+	done    chan struct{}
+	cancel  context.CancelFunc
+	waiters int
+
+	holidays []Holiday
+	err      error
+	source   Source
+}
+
+// NewCoalescingClient creates a coalescing decorator around next
+func NewCoalescingClient(next Client) *CoalescingClient {
+	return &CoalescingClient{
+		next:    next,
+		flights: make(map[cacheKey]*flight),
+	}
+}
+
+// GetHolidays joins an in-flight lookup for the same key or starts a new one
+func (c *CoalescingClient) GetHolidays(ctx context.Context, year int, countryCode string) ([]Holiday, error) {
+	key := cacheKey{year: year, country: strings.ToUpper(countryCode)}
+
+	c.mu.Lock()
+	f, ok := c.flights[key]
+	if !ok {
+		flightCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		f = &flight{done: make(chan struct{}), cancel: cancel}
+		c.flights[key] = f
+		go c.run(flightCtx, key, f, year, countryCode)
+	}
+	f.waiters++
+	c.mu.Unlock()
+
+	select {
+	case <-f.done:
+		setSource(ctx, f.source)
+		return copyHolidays(f.holidays), f.err
+	case <-ctx.Done():
+		c.leave(key, f)
+		return nil, ctx.Err()
+	}
+}
+
+// run performs the shared upstream call and wakes every waiter
+func (c *CoalescingClient) run(ctx context.Context, key cacheKey, f *flight, year int, countryCode string) {
+	ctx, src := WithSource(ctx)
+	f.holidays, f.err = c.next.GetHolidays(ctx, year, countryCode)
+	f.source = *src
+	f.cancel()
+
+	c.mu.Lock()
+	if c.flights[key] == f {
+		delete(c.flights, key)
+	}
+	c.mu.Unlock()
+
+	close(f.done)
+}
+
+// leave removes a waiter that gave up, abandoning the call if it was the last
+func (c *CoalescingClient) leave(key cacheKey, f *flight) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	f.waiters--
+	if f.waiters > 0 {
+		return
+	}
+
+	// Nobody is interested any more; later callers start a fresh call
+	f.cancel()
+	if c.flights[key] == f {
+		delete(c.flights, key)
+	}
+}
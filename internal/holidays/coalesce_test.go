@@ -0,0 +1,102 @@
+package holidays
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// blockingClient holds every call until release is closed
+type blockingClient struct {
+	calls   atomic.Int32
+	release chan struct{}
+}
+
+func (b *blockingClient) GetHolidays(ctx context.Context, year int, countryCode string) ([]Holiday, error) {
+	b.calls.Add(1)
+	select {
+	case <-b.release:
+		return []Holiday{{Date: "2025-01-01", CountryCode: countryCode}}, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func TestCoalescingClientSharesInFlightCalls(t *testing.T) {
+	upstream := &blockingClient{release: make(chan struct{})}
+	client := NewCoalescingClient(upstream)
+
+	leaderCtx, cancelLeader := context.WithCancel(context.Background())
+	leaderErr := make(chan error, 1)
+	go func() {
+		_, err := client.GetHolidays(leaderCtx, 2025, "DE")
+		leaderErr <- err
+	}()
+
+	// Wait for the leader to start the shared call
+	for upstream.calls.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	var wg sync.WaitGroup
+	results := make([]int, 5)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			holidays, err := client.GetHolidays(context.Background(), 2025, "DE")
+			if err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+			results[i] = len(holidays)
+		}(i)
+	}
+
+	// Cancelling the leader must not fail the followers
+	time.Sleep(10 * time.Millisecond)
+	cancelLeader()
+	if err := <-leaderErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected leader to be cancelled, got %v", err)
+	}
+
+	close(upstream.release)
+	wg.Wait()
+
+	if calls := upstream.calls.Load(); calls != 1 {
+		t.Errorf("Expected 1 upstream call, got %d", calls)
+	}
+	for i, n := range results {
+		if n != 1 {
+			t.Errorf("Follower %d got %d holidays", i, n)
+		}
+	}
+}
+
+func TestCoalescingClientCancelsAbandonedCalls(t *testing.T) {
+	upstream := &blockingClient{release: make(chan struct{})}
+	client := NewCoalescingClient(upstream)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		client.GetHolidays(ctx, 2025, "DE")
+		close(done)
+	}()
+	for upstream.calls.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	<-done
+
+	// With no waiters left a new caller starts a fresh call
+	close(upstream.release)
+	if _, err := client.GetHolidays(context.Background(), 2025, "DE"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if calls := upstream.calls.Load(); calls != 2 {
+		t.Errorf("Expected a second upstream call, got %d", calls)
+	}
+}
@@ -0,0 +1,129 @@
+package holidays
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// ErrOffline is returned in offline mode when no stored copy exists
+var ErrOffline = errors.New("holidays not available offline")
+
+// diskProvider is reported as the Source of data served from disk
+const diskProvider = "disk"
+
+// PersistentClient saves every successful lookup to a directory and serves
+// the saved copy, marked as stale, when the upstream fails. In offline mode
+// it only ever serves saved copies and never calls the upstream.
+type PersistentClient struct {
+	next    Client
+	dir     string
+	offline bool
+}
+
+// NewPersistentClient creates a persisting decorator around next storing files in dir
+func NewPersistentClient(next Client, dir string, offline bool) (*PersistentClient, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create holidays directory: %w", err)
+	}
+	return &PersistentClient{next: next, dir: dir, offline: offline}, nil
+}
+
+// GetHolidays fetches holidays from upstream, falling back to the saved copy
+func (c *PersistentClient) GetHolidays(ctx context.Context, year int, countryCode string) ([]Holiday, error) {
+	// The code becomes part of a file path, so only letters are acceptable
+	if !isCountryCode(countryCode) {
+		return nil, fmt.Errorf("invalid country code %q", countryCode)
+	}
+
+	if c.offline {
+		holidays, err := c.load(year, countryCode)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s %d", ErrOffline, countryCode, year)
+		}
+		setSource(ctx, Source{Provider: diskProvider})
+		return holidays, nil
+	}
+
+	holidays, err := c.next.GetHolidays(ctx, year, countryCode)
+	if err == nil {
+		if saveErr := c.save(year, countryCode, holidays); saveErr != nil {
+			log.Printf("Failed to save holidays for %s %d: %v", countryCode, year, saveErr)
+		}
+		return holidays, nil
+	}
+	if ctx.Err() != nil || IsPermanent(err) {
+		return nil, err
+	}
+
+	stored, loadErr := c.load(year, countryCode)
+	if loadErr != nil {
+		return nil, err
+	}
+	log.Printf("Serving stale holidays for %s %d: %v", countryCode, year, err)
+	setSource(ctx, Source{Provider: diskProvider, Stale: true})
+	return stored, nil
+}
+
+func (c *PersistentClient) path(year int, countryCode string) string {
+	return filepath.Join(c.dir, strings.ToUpper(countryCode), strconv.Itoa(year)+".json")
+}
+
+func (c *PersistentClient) load(year int, countryCode string) ([]Holiday, error) {
+	data, err := os.ReadFile(c.path(year, countryCode))
+	if err != nil {
+		return nil, err
+	}
+	var holidays []Holiday
+	if err := json.Unmarshal(data, &holidays); err != nil {
+		return nil, err
+	}
+	return holidays, nil
+}
+
+// save writes through a temporary file so readers never see partial data
+func (c *PersistentClient) save(year int, countryCode string, holidays []Holiday) error {
+	path := c.path(year, countryCode)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(holidays)
+	if err != nil {
+		return err
+	}
+
+	temp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(temp.Name())
+
+	if _, err := temp.Write(data); err != nil {
+		temp.Close()
+		return err
+	}
+	if err := temp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(temp.Name(), path)
+}
+
+// isCountryCode reports whether code looks like an ISO 3166-1 alpha-2 code
+func isCountryCode(code string) bool {
+	if len(code) != 2 {
+		return false
+	}
+	for _, r := range code {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,58 @@
+package holidays
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestPersistentClientServesStaleCopy(t *testing.T) {
+	upstream := &countingClient{}
+	client, err := NewPersistentClient(upstream, t.TempDir(), false)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	ctx, src := WithSource(context.Background())
+	if _, err := client.GetHolidays(ctx, 2025, "DE"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if src.Stale {
+		t.Error("Fresh result should not be stale")
+	}
+
+	upstream.err = errors.New("upstream down")
+	ctx, src = WithSource(context.Background())
+	holidays, err := client.GetHolidays(ctx, 2025, "DE")
+	if err != nil {
+		t.Fatalf("Expected stale copy, got error: %v", err)
+	}
+	if len(holidays) != 1 || !src.Stale {
+		t.Errorf("Expected 1 stale holiday, got %d (stale: %t)", len(holidays), src.Stale)
+	}
+
+	if _, err := client.GetHolidays(context.Background(), 2025, "FR"); err == nil {
+		t.Error("Expected error for a country that was never saved")
+	}
+}
+
+func TestPersistentClientOffline(t *testing.T) {
+	dir := t.TempDir()
+	online, _ := NewPersistentClient(&countingClient{}, dir, false)
+	if _, err := online.GetHolidays(context.Background(), 2025, "CA"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	upstream := &countingClient{}
+	offline, _ := NewPersistentClient(upstream, dir, true)
+
+	if _, err := offline.GetHolidays(context.Background(), 2025, "CA"); err != nil {
+		t.Errorf("Expected saved copy offline, got %v", err)
+	}
+	if _, err := offline.GetHolidays(context.Background(), 2025, "DE"); !errors.Is(err, ErrOffline) {
+		t.Errorf("Expected ErrOffline, got %v", err)
+	}
+	if upstream.calls != 0 {
+		t.Errorf("Offline client called upstream %d times", upstream.calls)
+	}
+}
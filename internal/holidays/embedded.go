@@ -0,0 +1,26 @@
+package holidays
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+)
+
+//go:generate go run ../../cmd/holidaysgen -out data/holidays.json
+
+// embeddedDataset is the holiday dataset bundled into the binary. It covers
+// major shipping countries for a few years and is regenerated with
+// cmd/holidaysgen.
+//
+//go:embed data/holidays.json
+var embeddedDataset []byte
+
+// NewEmbeddedClient creates a client serving the bundled dataset, so that
+// holidays can be answered without network access
+func NewEmbeddedClient() (*StaticClient, error) {
+	var holidays []Holiday
+	if err := json.Unmarshal(embeddedDataset, &holidays); err != nil {
+		return nil, fmt.Errorf("failed to decode embedded holiday dataset: %w", err)
+	}
+	return NewStaticClient(holidays)
+}
@@ -0,0 +1,47 @@
+package holidays
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestEmbeddedClient(t *testing.T) {
+	client, err := NewEmbeddedClient()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		year    int
+		country string
+		date    string
+		name    string
+	}{
+		{2025, "DE", "2025-10-03", "German Unity Day"},
+		{2025, "US", "2025-11-27", "Thanksgiving Day"},
+		{2026, "GB", "2026-12-28", "St. Stephen's Day"},
+		{2027, "NL", "2027-04-27", "King's Day"},
+	}
+
+	for _, tt := range tests {
+		holidays, err := client.GetHolidays(context.Background(), tt.year, tt.country)
+		if err != nil {
+			t.Errorf("Unexpected error for %s %d: %v", tt.country, tt.year, err)
+			continue
+		}
+		found := false
+		for _, holiday := range holidays {
+			if holiday.Date == tt.date && holiday.Name == tt.name {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("Expected %s on %s for %s", tt.name, tt.date, tt.country)
+		}
+	}
+
+	if _, err := client.GetHolidays(context.Background(), 1990, "DE"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound outside the dataset, got %v", err)
+	}
+}
@@ -0,0 +1,125 @@
+package holidays
+
+import (
+	"context"
+	"time"
+)
+
+// Shipment stages reported on a Slip
+const (
+	StagePickup   = "pickup"
+	StageTransit  = "transit"
+	StageDelivery = "delivery"
+)
+
+// Shipment describes a delivery between two countries
+type Shipment struct {
+	Origin      string
+	Destination string
+	// Transit lists the countries the shipment passes through
+	Transit     []string
+	Pickup      time.Time
+	TransitDays int
+}
+
+// Slip is a holiday that delayed a shipment
+type Slip struct {
+	Date        string `json:"date"`
+	CountryCode string `json:"countryCode"`
+	Name        string `json:"name"`
+	Stage       string `json:"stage"`
+}
+
+// Estimate is the adjusted schedule of a shipment
+type Estimate struct {
+	// Pickup is the first business day in the origin on or after the
+	// requested pickup date
+	Pickup time.Time
+	// Arrival is the first business day in the destination on which the
+	// shipment can be delivered
+	Arrival time.Time
+	Slips   []Slip
+}
+
+// EstimateArrival schedules a shipment around holidays. Pickup waits for a
+// business day in the origin. Transit runs every day except public holidays
+// in a transit country, when the shipment is held at the border. Delivery
+// waits for a business day in the destination.
+func (c *Calendar) EstimateArrival(ctx context.Context, shipment Shipment) (Estimate, error) {
+	var estimate Estimate
+
+	origin, err := c.days(shipment.Origin)
+	if err != nil {
+		return estimate, err
+	}
+	destination, err := c.days(shipment.Destination)
+	if err != nil {
+		return estimate, err
+	}
+	transit := make([]*businessDays, 0, len(shipment.Transit))
+	for _, country := range shipment.Transit {
+		days, err := c.days(country)
+		if err != nil {
+			return estimate, err
+		}
+		transit = append(transit, days)
+	}
+
+	estimate.Pickup, err = estimate.waitForBusinessDay(ctx, origin, truncateDay(shipment.Pickup), StagePickup)
+	if err != nil {
+		return estimate, err
+	}
+
+	date := estimate.Pickup
+	for remaining := shipment.TransitDays; remaining > 0; {
+		date = date.AddDate(0, 0, 1)
+		held := false
+		for _, days := range transit {
+			holidays, err := days.holidaysOn(ctx, date)
+			if err != nil {
+				return estimate, err
+			}
+			for _, holiday := range holidays {
+				estimate.slip(days, holiday, StageTransit)
+				held = true
+			}
+		}
+		if !held {
+			remaining--
+		}
+	}
+
+	estimate.Arrival, err = estimate.waitForBusinessDay(ctx, destination, date, StageDelivery)
+	return estimate, err
+}
+
+// waitForBusinessDay returns the first business day on or after date,
+// recording the holidays passed on the way
+func (e *Estimate) waitForBusinessDay(ctx context.Context, days *businessDays, date time.Time, stage string) (time.Time, error) {
+	for {
+		if days.weekend[date.Weekday()] {
+			date = date.AddDate(0, 0, 1)
+			continue
+		}
+		holidays, err := days.holidaysOn(ctx, date)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if len(holidays) == 0 {
+			return date, nil
+		}
+		for _, holiday := range holidays {
+			e.slip(days, holiday, stage)
+		}
+		date = date.AddDate(0, 0, 1)
+	}
+}
+
+func (e *Estimate) slip(days *businessDays, holiday Holiday, stage string) {
+	e.Slips = append(e.Slips, Slip{
+		Date:        holiday.Date,
+		CountryCode: days.country,
+		Name:        holiday.Name,
+		Stage:       stage,
+	})
+}
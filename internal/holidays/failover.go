@@ -0,0 +1,195 @@
+package holidays
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Provider is a named holiday data source used by a FailoverClient
+type Provider struct {
+	Name   string
+	Client Client
+}
+
+// BreakerOptions configures the circuit breaker kept for each provider
+type BreakerOptions struct {
+	// FailureThreshold is the number of consecutive failures that opens the
+	// circuit; zero disables circuit breaking
+	FailureThreshold int
+	// Cooldown is how long an open circuit skips the provider before a
+	// single trial request is let through
+	Cooldown time.Duration
+}
+
+// Circuit states reported by ProviderHealth
+const (
+	CircuitClosed   = "closed"
+	CircuitOpen     = "open"
+	CircuitHalfOpen = "half-open"
+)
+
+// ProviderHealth is a snapshot of a provider's recent behaviour
+type ProviderHealth struct {
+	Name                string    `json:"name"`
+	State               string    `json:"state"`
+	ConsecutiveFailures int       `json:"consecutiveFailures"`
+	LastError           string    `json:"lastError,omitempty"`
+	LastFailure         time.Time `json:"lastFailure,omitempty"`
+}
+
+// FailoverClient tries an ordered list of providers until one answers. Each
+// provider has its own circuit breaker so that a provider which keeps
+// failing is skipped until its cooldown has passed. The provider that
+// served a lookup is recorded in the lookup's Source.
+type FailoverClient struct {
+	providers []*providerState
+	breaker   BreakerOptions
+}
+
+type providerState struct {
+	Provider
+
+	mu          sync.Mutex
+	failures    int
+	openedAt    time.Time
+	trialActive bool
+	lastError   string
+	lastFailure time.Time
+}
+
+// NewFailoverClient creates a client trying providers in order
+func NewFailoverClient(breaker BreakerOptions, providers ...Provider) *FailoverClient {
+	c := &FailoverClient{breaker: breaker}
+	for _, provider := range providers {
+		c.providers = append(c.providers, &providerState{Provider: provider})
+	}
+	return c
+}
+
+// GetHolidays returns the first successful answer from the providers.
+// Permanent errors, such as a provider not knowing a country, move on to
+// the next provider without counting against the provider's health.
+func (c *FailoverClient) GetHolidays(ctx context.Context, year int, countryCode string) ([]Holiday, error) {
+	var errs []error
+	permanent := true
+
+	for _, provider := range c.providers {
+		if !provider.allow(c.breaker) {
+			errs = append(errs, fmt.Errorf("%s: circuit open", provider.Name))
+			permanent = false
+			continue
+		}
+
+		holidays, err := provider.Client.GetHolidays(ctx, year, countryCode)
+		if err == nil {
+			provider.succeeded()
+			setProvider(ctx, provider.Name)
+			return holidays, nil
+		}
+		if ctx.Err() != nil {
+			provider.release()
+			return nil, err
+		}
+
+		if IsPermanent(err) {
+			provider.release()
+		} else {
+			provider.failed(c.breaker, err)
+			permanent = false
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", provider.Name, err))
+	}
+
+	if len(errs) == 1 {
+		return nil, errs[0]
+	}
+	if !permanent {
+		// Only report a permanent failure when every provider gave one, so
+		// that callers such as PersistentClient still fall back on outages
+		return nil, fmt.Errorf("all providers failed: %v", errors.Join(errs...))
+	}
+	return nil, fmt.Errorf("all providers failed: %w", errors.Join(errs...))
+}
+
+// Health reports the state of every provider, in failover order
+func (c *FailoverClient) Health() []ProviderHealth {
+	health := make([]ProviderHealth, 0, len(c.providers))
+	for _, provider := range c.providers {
+		health = append(health, provider.health(c.breaker))
+	}
+	return health
+}
+
+// allow reports whether a request may be sent to the provider. Once the
+// cooldown of an open circuit has passed, a single trial is let through.
+func (p *providerState) allow(breaker BreakerOptions) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	switch p.state(breaker) {
+	case CircuitClosed:
+		return true
+	case CircuitHalfOpen:
+		if p.trialActive {
+			return false
+		}
+		p.trialActive = true
+		return true
+	default:
+		return false
+	}
+}
+
+func (p *providerState) succeeded() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.failures = 0
+	p.trialActive = false
+}
+
+func (p *providerState) failed(breaker BreakerOptions, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.failures++
+	p.lastError = err.Error()
+	p.lastFailure = time.Now()
+	if breaker.FailureThreshold > 0 && (p.trialActive || p.failures == breaker.FailureThreshold) {
+		p.openedAt = time.Now()
+	}
+	p.trialActive = false
+}
+
+// release ends a trial without judging the provider
+func (p *providerState) release() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.trialActive = false
+}
+
+// state returns the circuit state. The caller must hold p.mu.
+func (p *providerState) state(breaker BreakerOptions) string {
+	if breaker.FailureThreshold <= 0 || p.failures < breaker.FailureThreshold {
+		return CircuitClosed
+	}
+	if time.Since(p.openedAt) < breaker.Cooldown {
+		return CircuitOpen
+	}
+	return CircuitHalfOpen
+}
+
+func (p *providerState) health(breaker BreakerOptions) ProviderHealth {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return ProviderHealth{
+		Name:                p.Name,
+		State:               p.state(breaker),
+		ConsecutiveFailures: p.failures,
+		LastError:           p.lastError,
+		LastFailure:         p.lastFailure,
+	}
+}
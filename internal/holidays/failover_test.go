@@ -0,0 +1,100 @@
+package holidays
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestFailoverClientFallsBackToNextProvider(t *testing.T) {
+	primary := &countingClient{err: errors.New("connection refused")}
+	secondary := &countingClient{}
+	client := NewFailoverClient(BreakerOptions{}, Provider{Name: "primary", Client: primary}, Provider{Name: "secondary", Client: secondary})
+
+	ctx, src := WithSource(context.Background())
+	holidays, err := client.GetHolidays(ctx, 2025, "DE")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(holidays) != 1 {
+		t.Errorf("Expected 1 holiday, got %d", len(holidays))
+	}
+	if src.Provider != "secondary" {
+		t.Errorf("Expected provider secondary, got %q", src.Provider)
+	}
+	if primary.calls != 1 || secondary.calls != 1 {
+		t.Errorf("Expected one call to each provider, got %d and %d", primary.calls, secondary.calls)
+	}
+}
+
+func TestFailoverClientOpensCircuit(t *testing.T) {
+	primary := &countingClient{err: errors.New("connection refused")}
+	secondary := &countingClient{}
+	client := NewFailoverClient(BreakerOptions{FailureThreshold: 2, Cooldown: 50 * time.Millisecond},
+		Provider{Name: "primary", Client: primary}, Provider{Name: "secondary", Client: secondary})
+	ctx := context.Background()
+
+	for i := 0; i < 4; i++ {
+		client.GetHolidays(ctx, 2025, "DE")
+	}
+	if primary.calls != 2 {
+		t.Errorf("Expected the open circuit to skip the primary after 2 failures, got %d calls", primary.calls)
+	}
+	if state := client.Health()[0].State; state != CircuitOpen {
+		t.Errorf("Expected primary circuit to be %s, got %s", CircuitOpen, state)
+	}
+
+	// After the cooldown a successful trial closes the circuit again
+	time.Sleep(60 * time.Millisecond)
+	primary.err = nil
+	ctx, src := WithSource(ctx)
+	if _, err := client.GetHolidays(ctx, 2025, "DE"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if src.Provider != "primary" {
+		t.Errorf("Expected provider primary, got %q", src.Provider)
+	}
+	health := client.Health()[0]
+	if health.State != CircuitClosed || health.ConsecutiveFailures != 0 {
+		t.Errorf("Expected primary circuit to be closed, got %+v", health)
+	}
+}
+
+func TestFailoverClientPermanentErrorsKeepProviderHealthy(t *testing.T) {
+	primary := &countingClient{err: &StatusError{StatusCode: 404}}
+	secondary := &countingClient{err: ErrNotFound}
+	client := NewFailoverClient(BreakerOptions{FailureThreshold: 1, Cooldown: time.Minute},
+		Provider{Name: "primary", Client: primary}, Provider{Name: "secondary", Client: secondary})
+
+	for i := 0; i < 3; i++ {
+		_, err := client.GetHolidays(context.Background(), 2025, "XX")
+		if !IsPermanent(err) {
+			t.Errorf("Expected a permanent error, got %v", err)
+		}
+	}
+	if primary.calls != 3 {
+		t.Errorf("Expected the primary to be tried every time, got %d calls", primary.calls)
+	}
+	if state := client.Health()[0].State; state != CircuitClosed {
+		t.Errorf("Expected primary circuit to stay closed, got %s", state)
+	}
+}
+
+func TestStaticClient(t *testing.T) {
+	client, err := NewStaticClient([]Holiday{
+		{Date: "2025-12-25", Name: "Christmas Day", CountryCode: "GB"},
+		{Date: "2026-12-25", Name: "Christmas Day", CountryCode: "GB"},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	holidays, err := client.GetHolidays(context.Background(), 2025, "gb")
+	if err != nil || len(holidays) != 1 {
+		t.Errorf("Expected 1 holiday, got %d (%v)", len(holidays), err)
+	}
+	if _, err := client.GetHolidays(context.Background(), 2024, "GB"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,55 @@
+package holidays
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// FeedClient fetches holidays from a custom feed. The URL template may
+// contain {year} and {country} placeholders, and the feed must answer with
+// a JSON array of holidays in the same shape as the Nager.Date API.
+type FeedClient struct {
+	urlTemplate string
+	httpClient  *http.Client
+}
+
+// NewFeedClient creates a client for the feed at urlTemplate
+func NewFeedClient(urlTemplate string) *FeedClient {
+	return &FeedClient{
+		urlTemplate: urlTemplate,
+		httpClient:  &http.Client{Timeout: DefaultTimeout},
+	}
+}
+
+// GetHolidays fetches holidays for a specific country and year from the feed
+func (c *FeedClient) GetHolidays(ctx context.Context, year int, countryCode string) ([]Holiday, error) {
+	url := strings.NewReplacer("{year}", strconv.Itoa(year), "{country}", countryCode).Replace(c.urlTemplate)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch holidays: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, &StatusError{
+			StatusCode: resp.StatusCode,
+			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+		}
+	}
+
+	var holidays []Holiday
+	if err := json.NewDecoder(resp.Body).Decode(&holidays); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMalformedResponse, err)
+	}
+	return holidays, nil
+}
@@ -0,0 +1,143 @@
+package holidays
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ErrUnknownSubdivision is returned when a subdivision does not appear in a
+// country's holiday data
+var ErrUnknownSubdivision = errors.New("unknown subdivision")
+
+// subdivisionPattern matches ISO 3166-2 codes such as CA-ON or DE-BY
+var subdivisionPattern = regexp.MustCompile(`^[A-Z]{2}-[A-Z0-9]{1,3}$`)
+
+// HolidayTypes are the holiday types known to the Nager.Date API
+var HolidayTypes = []string{"Public", "Bank", "School", "Authorities", "Optional", "Observance"}
+
+// Filter narrows down which holidays apply. The zero Filter keeps every
+// holiday.
+type Filter struct {
+	// Subdivisions limits the regional holidays of their countries to those
+	// observed in the subdivision, e.g. DE-BY
+	Subdivisions []string
+	// IncludeTypes keeps only holidays of these types, if set
+	IncludeTypes []string
+	// ExcludeTypes drops holidays of these types
+	ExcludeTypes []string
+}
+
+// ParseHolidayType returns the canonical spelling of a holiday type
+func ParseHolidayType(value string) (string, error) {
+	for _, holidayType := range HolidayTypes {
+		if strings.EqualFold(holidayType, value) {
+			return holidayType, nil
+		}
+	}
+	return "", fmt.Errorf("invalid holiday type %q, expected one of %s", value, strings.Join(HolidayTypes, ", "))
+}
+
+// ParseSubdivision normalizes a subdivision code and checks its format
+func ParseSubdivision(code string) (string, error) {
+	code = strings.ToUpper(code)
+	if !subdivisionPattern.MatchString(code) {
+		return "", fmt.Errorf("invalid subdivision %q, expected a code such as DE-BY", code)
+	}
+	return code, nil
+}
+
+// subdivisionsOf returns the filter's subdivisions in country
+func (f Filter) subdivisionsOf(country string) []string {
+	var subdivisions []string
+	for _, subdivision := range f.Subdivisions {
+		if len(subdivision) > 2 && strings.EqualFold(subdivision[:2], country) {
+			subdivisions = append(subdivisions, subdivision)
+		}
+	}
+	return subdivisions
+}
+
+// inSubdivision reports whether a regional holiday of country is observed
+// in one of the filter's subdivisions
+func (f Filter) inSubdivision(country string, holiday Holiday) bool {
+	for _, subdivision := range f.subdivisionsOf(country) {
+		for _, county := range holiday.Counties {
+			if strings.EqualFold(county, subdivision) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Allows reports whether a holiday of country passes the filter. Countries
+// without a subdivision in the filter keep all their regional holidays.
+func (f Filter) Allows(country string, holiday Holiday) bool {
+	if len(f.subdivisionsOf(country)) > 0 && !holiday.Global && !f.inSubdivision(country, holiday) {
+		return false
+	}
+	return f.allowsType(holiday)
+}
+
+// allowsType reports whether any of the holiday's types is included and not
+// excluded, so a day that is both a public and a bank holiday stays a
+// public holiday when bank holidays are excluded. Holidays without a type
+// count as public holidays.
+func (f Filter) allowsType(holiday Holiday) bool {
+	types := holiday.Types
+	if len(types) == 0 {
+		types = []string{"Public"}
+	}
+	for _, holidayType := range types {
+		if (len(f.IncludeTypes) == 0 || containsFold(f.IncludeTypes, holidayType)) && !containsFold(f.ExcludeTypes, holidayType) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// Apply returns the holidays of country that pass the filter
+func (f Filter) Apply(country string, holidays []Holiday) []Holiday {
+	var kept []Holiday
+	for _, holiday := range holidays {
+		if f.Allows(country, holiday) {
+			kept = append(kept, holiday)
+		}
+	}
+	return kept
+}
+
+// Validate checks that the filter's subdivisions of country exist. For the
+// countries in knownSubdivisions that is the ISO 3166-2 list; for the others
+// a subdivision must appear in the holidays given, which cover one period.
+func (f Filter) Validate(country string, holidays []Holiday) error {
+	known, listed := knownSubdivisions[strings.ToUpper(country)]
+	for _, subdivision := range f.subdivisionsOf(country) {
+		if listed && !containsFold(known, subdivision) || !listed && !hasSubdivision(holidays, subdivision) {
+			return fmt.Errorf("%w %s for %s", ErrUnknownSubdivision, subdivision, strings.ToUpper(country))
+		}
+	}
+	return nil
+}
+
+func hasSubdivision(holidays []Holiday, subdivision string) bool {
+	for _, holiday := range holidays {
+		for _, county := range holiday.Counties {
+			if strings.EqualFold(county, subdivision) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,193 @@
+package holidays
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFilterSubdivisions(t *testing.T) {
+	holidays := []Holiday{
+		{Date: "2025-01-01", Name: "New Year's Day", Global: true},
+		{Date: "2025-01-06", Name: "Epiphany", Counties: []string{"DE-BW", "DE-BY", "DE-ST"}},
+		{Date: "2025-10-31", Name: "Reformation Day", Counties: []string{"DE-SN"}},
+	}
+
+	tests := []struct {
+		name    string
+		filter  Filter
+		country string
+		want    int
+	}{
+		{"no filter", Filter{}, "DE", 3},
+		{"subdivision", Filter{Subdivisions: []string{"DE-BY"}}, "DE", 2},
+		{"several subdivisions", Filter{Subdivisions: []string{"DE-BY", "DE-SN"}}, "DE", 3},
+		{"other country", Filter{Subdivisions: []string{"CA-ON"}}, "DE", 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := len(tt.filter.Apply(tt.country, holidays)); got != tt.want {
+				t.Errorf("Expected %d holidays, got %d", tt.want, got)
+			}
+		})
+	}
+
+	if err := (Filter{Subdivisions: []string{"DE-BY"}}).Validate("DE", holidays); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if err := (Filter{Subdivisions: []string{"DE-XX"}}).Validate("DE", holidays); !errors.Is(err, ErrUnknownSubdivision) {
+		t.Errorf("Expected ErrUnknownSubdivision, got %v", err)
+	}
+}
+
+func TestFilterValidateSubdivisionWithoutHolidays(t *testing.T) {
+	rules := NewRuleClient(DefaultRules)
+
+	// Berlin has no regional holiday in DefaultRules, yet it is a state
+	holidays, _ := rules.GetHolidays(context.Background(), 2018, "DE")
+	if err := (Filter{Subdivisions: []string{"DE-BE"}}).Validate("DE", holidays); err != nil {
+		t.Errorf("Unexpected error for DE-BE: %v", err)
+	}
+
+	// Countries without a list are checked against their holidays
+	dutch := []Holiday{{Date: "2025-01-01", Name: "New Year's Day", Global: true}}
+	if err := (Filter{Subdivisions: []string{"NL-NH"}}).Validate("NL", dutch); !errors.Is(err, ErrUnknownSubdivision) {
+		t.Errorf("Expected ErrUnknownSubdivision for NL-NH, got %v", err)
+	}
+}
+
+func TestReformationDayStates(t *testing.T) {
+	rules := NewRuleClient(DefaultRules)
+	tests := []struct {
+		year     int
+		counties int
+	}{
+		{1989, 0},
+		{1995, 5},
+		{2017, 5},
+		{2018, 9},
+	}
+
+	for _, tt := range tests {
+		holidays, _ := rules.GetHolidays(context.Background(), tt.year, "DE")
+		var reformation []Holiday
+		for _, holiday := range holidays {
+			if holiday.Name == "Reformation Day" {
+				reformation = append(reformation, holiday)
+			}
+		}
+		if tt.counties == 0 {
+			if len(reformation) != 0 {
+				t.Errorf("Expected no Reformation Day in %d, got %v", tt.year, reformation)
+			}
+			continue
+		}
+		if len(reformation) != 1 || len(reformation[0].Counties) != tt.counties {
+			t.Errorf("Expected one Reformation Day in %d states in %d, got %v", tt.counties, tt.year, reformation)
+		}
+	}
+}
+
+func TestParseSubdivision(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+		valid bool
+	}{
+		{"de-by", "DE-BY", true},
+		{"GB-SCT", "GB-SCT", true},
+		{"DE", "", false},
+		{"DE-BAVARIA", "", false},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseSubdivision(tt.value)
+		if (err == nil) != tt.valid || got != tt.want {
+			t.Errorf("Expected %q (valid %v) for %q, got %q (%v)", tt.want, tt.valid, tt.value, got, err)
+		}
+	}
+}
+
+func TestCalendarFilteredBySubdivision(t *testing.T) {
+	calendar := NewCalendar(NewService(NewRuleClient(DefaultRules)))
+	ctx := context.Background()
+
+	// Epiphany, Tuesday 6 January 2026, is only a holiday in some states
+	next, _ := calendar.AddBusinessDays(ctx, "DE", date("2026-01-05"), 1)
+	if got := next.Format(DateLayout); got != "2026-01-06" {
+		t.Errorf("Expected 2026-01-06 nationwide, got %s", got)
+	}
+	bavaria := calendar.Filtered(Filter{Subdivisions: []string{"DE-BY"}})
+	next, _ = bavaria.AddBusinessDays(ctx, "DE", date("2026-01-05"), 1)
+	if got := next.Format(DateLayout); got != "2026-01-07" {
+		t.Errorf("Expected 2026-01-07 in Bavaria, got %s", got)
+	}
+
+	unknown := calendar.Filtered(Filter{Subdivisions: []string{"DE-XX"}})
+	if _, err := unknown.AddBusinessDays(ctx, "DE", date("2026-01-05"), 1); !errors.Is(err, ErrUnknownSubdivision) {
+		t.Errorf("Expected ErrUnknownSubdivision, got %v", err)
+	}
+}
+
+func TestFilterTypes(t *testing.T) {
+	holidays := []Holiday{
+		{Date: "2025-01-01", Name: "New Year's Day", Global: true, Types: []string{"Public"}},
+		{Date: "2025-08-25", Name: "Summer Bank Holiday", Global: true, Types: []string{"Bank"}},
+		{Date: "2025-12-26", Name: "Boxing Day", Global: true, Types: []string{"Public", "Bank"}},
+		{Date: "2025-05-04", Name: "Star Wars Day", Global: true, Types: []string{"Observance"}},
+		{Date: "2025-12-25", Name: "Christmas Day", Global: true},
+	}
+
+	tests := []struct {
+		name   string
+		filter Filter
+		want   []string
+	}{
+		{"no filter", Filter{}, []string{"2025-01-01", "2025-08-25", "2025-12-26", "2025-05-04", "2025-12-25"}},
+		{"include", Filter{IncludeTypes: []string{"Bank"}}, []string{"2025-08-25", "2025-12-26"}},
+		{"exclude", Filter{ExcludeTypes: []string{"bank", "Observance"}}, []string{"2025-01-01", "2025-12-26", "2025-12-25"}},
+		{"include and exclude", Filter{IncludeTypes: []string{"Public", "Bank"}, ExcludeTypes: []string{"Public"}}, []string{"2025-08-25", "2025-12-26"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, holiday := range tt.filter.Apply("GB", holidays) {
+				got = append(got, holiday.Date)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+
+	if got, err := ParseHolidayType("bank"); err != nil || got != "Bank" {
+		t.Errorf("Expected Bank, got %q (%v)", got, err)
+	}
+	if _, err := ParseHolidayType("Holiday"); err == nil {
+		t.Error("Expected an error for an unknown type")
+	}
+}
+
+func TestCalendarFilteredByType(t *testing.T) {
+	calendar := NewCalendar(NewService(NewRuleClient(map[string][]Rule{
+		"GB": {
+			{Name: "Summer Bank Holiday", Date: LastWeekday(time.Monday, time.August), Types: []string{"Bank"}},
+		},
+	})))
+	ctx := context.Background()
+
+	// Finance treats bank holidays as days off, carriers work through them
+	next, _ := calendar.AddBusinessDays(ctx, "GB", date("2025-08-22"), 1)
+	if got := next.Format(DateLayout); got != "2025-08-26" {
+		t.Errorf("Expected 2025-08-26 with bank holidays, got %s", got)
+	}
+	carriers := calendar.Filtered(Filter{ExcludeTypes: []string{"Bank"}})
+	next, _ = carriers.AddBusinessDays(ctx, "GB", date("2025-08-22"), 1)
+	if got := next.Format(DateLayout); got != "2025-08-25" {
+		t.Errorf("Expected 2025-08-25 without bank holidays, got %s", got)
+	}
+}
@@ -2,6 +2,7 @@ package holidays
 
 import (
 	"context"
+	"encoding/json"
 	"sync"
 )
 
@@ -15,7 +16,36 @@ type Holiday struct {
 	Global      bool     `json:"global"`
 	Counties    []string `json:"counties,omitempty"`
 	LaunchYear  *int     `json:"launchYear,omitempty"`
-	Type        []string `json:"type"`
+	Types       []string `json:"types"`
+}
+
+// MarshalJSON writes the types under both "types" and the deprecated
+// "type", which clients of earlier versions of this service read
+func (h Holiday) MarshalJSON() ([]byte, error) {
+	type plain Holiday
+	return json.Marshal(struct {
+		plain
+		LegacyType []string `json:"type"`
+	}{plain(h), h.Types})
+}
+
+// UnmarshalJSON decodes a holiday as served by the Nager.Date v3 API, whose
+// types are in "types". The "type" field of older saved copies and
+// datasets is read as well.
+func (h *Holiday) UnmarshalJSON(data []byte) error {
+	type plain Holiday
+	var decoded struct {
+		plain
+		LegacyType []string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	*h = Holiday(decoded.plain)
+	if len(h.Types) == 0 {
+		h.Types = decoded.LegacyType
+	}
+	return nil
 }
 
 // CountryResult represents the result of fetching holidays for a single country
@@ -23,6 +53,14 @@ type CountryResult struct {
 	CountryCode string    `json:"countryCode"`
 	Holidays    []Holiday `json:"holidays,omitempty"`
 	Error       string    `json:"error,omitempty"`
+	// Err is the error behind Error, kept so that callers can tell an
+	// unknown country from an outage
+	Err error `json:"-"`
+	// Provider names the data source that served the holidays
+	Provider string `json:"provider,omitempty"`
+	// Stale is set when the holidays were served from a saved copy because
+	// the upstream could not be reached
+	Stale bool `json:"stale,omitempty"`
 }
 
 // Service handles holiday data retrieval
@@ -32,40 +70,104 @@ type Service interface {
 
 // HolidayService implements the Service interface
 type HolidayService struct {
-	client Client
+	client      Client
+	concurrency int
+}
+
+// ServiceOption customises a HolidayService
+type ServiceOption func(*HolidayService)
+
+// WithConcurrency limits how many countries a single call fetches at once.
+// Zero or less means no limit.
+func WithConcurrency(n int) ServiceOption {
+	return func(s *HolidayService) {
+		s.concurrency = n
+	}
 }
 
 // NewService creates a new holiday service
-func NewService(client Client) Service {
-	return &HolidayService{client: client}
+func NewService(client Client, opts ...ServiceOption) Service {
+	s := &HolidayService{client: client}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 // GetHolidaysForCountries fetches holidays for multiple countries concurrently
 func (s *HolidayService) GetHolidaysForCountries(ctx context.Context, year int, countryCodes []string) []CountryResult {
+	results := make([]CountryResult, len(countryCodes))
+	for fetched := range s.fetch(ctx, year, countryCodes) {
+		results[fetched.index] = fetched.result
+	}
+	return results
+}
+
+// StreamHolidaysForCountries fetches holidays for multiple countries
+// concurrently and calls emit with each result as soon as it is ready.
+// emit is called from the calling goroutine, in the order fetches finish.
+func (s *HolidayService) StreamHolidaysForCountries(ctx context.Context, year int, countryCodes []string, emit func(CountryResult)) {
+	for fetched := range s.fetch(ctx, year, countryCodes) {
+		emit(fetched.result)
+	}
+}
+
+// indexedResult is a fetched result and the position of its country in
+// the request
+type indexedResult struct {
+	index  int
+	result CountryResult
+}
+
+// fetch starts fetching every country and returns a channel delivering the
+// results as they finish. The channel is closed once all have been sent.
+func (s *HolidayService) fetch(ctx context.Context, year int, countryCodes []string) <-chan indexedResult {
 	var (
 		wg      sync.WaitGroup
-		results = make([]CountryResult, len(countryCodes))
+		results = make(chan indexedResult, len(countryCodes))
+		sem     chan struct{}
 	)
+	if s.concurrency > 0 {
+		sem = make(chan struct{}, s.concurrency)
+	}
 
-	// Process each country concurrently
+	// Process each country concurrently, at most s.concurrency at a time
 	for i, countryCode := range countryCodes {
 		wg.Add(1)
 		go func(index int, code string) {
 			defer wg.Done()
 
+			if sem != nil {
+				select {
+				case sem <- struct{}{}:
+					defer func() { <-sem }()
+				case <-ctx.Done():
+					results <- indexedResult{index, CountryResult{CountryCode: code, Error: ctx.Err().Error(), Err: ctx.Err()}}
+					return
+				}
+			}
+
+			ctx, src := WithSource(ctx)
 			holidays, err := s.client.GetHolidays(ctx, year, code)
-			results[index] = CountryResult{
+			result := CountryResult{
 				CountryCode: code,
+				Provider:    src.Provider,
+				Stale:       src.Stale,
 			}
 
 			if err != nil {
-				results[index].Error = err.Error()
+				result.Error = err.Error()
+				result.Err = err
 			} else {
-				results[index].Holidays = holidays
+				result.Holidays = holidays
 			}
+			results <- indexedResult{index, result}
 		}(i, countryCode)
 	}
 
-	wg.Wait()
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
 	return results
 }
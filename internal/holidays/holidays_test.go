@@ -2,7 +2,10 @@ package holidays
 
 import (
 	"context"
+	"errors"
+	"sync"
 	"testing"
+	"time"
 )
 
 type mockClient struct {
@@ -48,3 +51,86 @@ func TestGetHolidaysForCountries(t *testing.T) {
 		}
 	}
 }
+
+// concurrencyClient records the highest number of overlapping calls
+type concurrencyClient struct {
+	mu      sync.Mutex
+	current int
+	max     int
+}
+
+func (c *concurrencyClient) GetHolidays(ctx context.Context, year int, countryCode string) ([]Holiday, error) {
+	c.mu.Lock()
+	c.current++
+	if c.current > c.max {
+		c.max = c.current
+	}
+	c.mu.Unlock()
+
+	time.Sleep(10 * time.Millisecond)
+
+	c.mu.Lock()
+	c.current--
+	c.mu.Unlock()
+	return nil, nil
+}
+
+func TestGetHolidaysForCountriesConcurrencyLimit(t *testing.T) {
+	client := &concurrencyClient{}
+	service := NewService(NewLimitedClient(client, 3), WithConcurrency(2))
+
+	countries := []string{"CA", "DE", "FR", "GB", "NL", "US"}
+	results := service.GetHolidaysForCountries(context.Background(), 2025, countries)
+
+	if len(results) != len(countries) {
+		t.Errorf("Expected %d results, got %d", len(countries), len(results))
+	}
+	if client.max > 2 {
+		t.Errorf("Expected at most 2 concurrent fetches, got %d", client.max)
+	}
+}
+
+func TestLimitedClientSharedAcrossRequests(t *testing.T) {
+	client := &concurrencyClient{}
+	limited := NewLimitedClient(client, 3)
+
+	// Each request may fetch 4 countries at once, but the upstream budget
+	// is shared by all of them
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			service := NewService(limited, WithConcurrency(4))
+			service.GetHolidaysForCountries(context.Background(), 2025, []string{"CA", "DE", "FR", "GB", "NL", "US"})
+		}()
+	}
+	wg.Wait()
+
+	if client.max > 3 {
+		t.Errorf("Expected at most 3 concurrent upstream calls, got %d", client.max)
+	}
+	if client.max < 2 {
+		t.Errorf("Expected requests to share the budget concurrently, got at most %d calls at once", client.max)
+	}
+}
+
+func TestLimitedClientGivesUpWhenCancelled(t *testing.T) {
+	upstream := &blockingClient{release: make(chan struct{})}
+	limited := NewLimitedClient(upstream, 1)
+	defer close(upstream.release)
+
+	go limited.GetHolidays(context.Background(), 2025, "DE")
+	for upstream.calls.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := limited.GetHolidays(ctx, 2025, "FR"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the waiting call to give up, got %v", err)
+	}
+	if calls := upstream.calls.Load(); calls != 1 {
+		t.Errorf("Expected the waiting call not to reach upstream, got %d calls", calls)
+	}
+}
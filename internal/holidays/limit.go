@@ -0,0 +1,28 @@
+package holidays
+
+import "context"
+
+// LimitedClient decorates a Client with a concurrency budget shared by
+// every caller, bounding the number of simultaneous upstream calls
+// regardless of how many requests are being served
+type LimitedClient struct {
+	next Client
+	sem  chan struct{}
+}
+
+// NewLimitedClient creates a decorator allowing at most n concurrent calls to next
+func NewLimitedClient(next Client, n int) *LimitedClient {
+	return &LimitedClient{next: next, sem: make(chan struct{}, n)}
+}
+
+// GetHolidays waits for a free slot, giving up if ctx is done first
+func (c *LimitedClient) GetHolidays(ctx context.Context, year int, countryCode string) ([]Holiday, error) {
+	select {
+	case c.sem <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	defer func() { <-c.sem }()
+
+	return c.next.GetHolidays(ctx, year, countryCode)
+}
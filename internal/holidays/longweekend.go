@@ -0,0 +1,135 @@
+package holidays
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// LongWeekend is a break of at least three consecutive days off built from
+// weekends and public holidays. NeedBridgeDay is set when it only comes
+// about by taking the listed bridge days off as well.
+type LongWeekend struct {
+	StartDate     string    `json:"startDate"`
+	EndDate       string    `json:"endDate"`
+	DayCount      int       `json:"dayCount"`
+	NeedBridgeDay bool      `json:"needBridgeDay"`
+	BridgeDays    []string  `json:"bridgeDays,omitempty"`
+	Holidays      []Holiday `json:"holidays"`
+}
+
+// dayOff is one day in the span searched for long weekends
+type dayOff struct {
+	date     time.Time
+	off      bool
+	holidays []Holiday
+}
+
+// LongWeekends finds the long weekends around the nationwide holidays of
+// country in year. A single working day between a holiday and a weekend is
+// suggested as a bridge day when taking it off makes a longer break.
+func (c *Calendar) LongWeekends(ctx context.Context, country string, year int) ([]LongWeekend, error) {
+	days, err := c.days(country)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := days.year(ctx, year); err != nil {
+		return nil, err
+	}
+
+	// Look a week past either end of the year so breaks spanning New Year
+	// are seen whole
+	first := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, -7)
+	last := time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC).AddDate(0, 0, 7)
+
+	var span []dayOff
+	for date := first; !date.After(last); date = date.AddDate(0, 0, 1) {
+		day := dayOff{date: date, off: days.weekend[date.Weekday()]}
+		// Neighbouring years may be missing from the data; their weekends
+		// still count
+		if holidays, err := days.holidaysOn(ctx, date); err == nil && len(holidays) > 0 {
+			day.off = true
+			day.holidays = holidays
+		}
+		span = append(span, day)
+	}
+
+	// Split the span into runs of consecutive days off
+	type run struct{ start, end int }
+	var runs []run
+	for i := 0; i < len(span); i++ {
+		if !span[i].off {
+			continue
+		}
+		j := i
+		for j+1 < len(span) && span[j+1].off {
+			j++
+		}
+		runs = append(runs, run{i, j})
+		i = j
+	}
+
+	var (
+		weekends []LongWeekend
+		seen     = make(map[string]bool)
+	)
+	add := func(start, end int, bridge int) {
+		weekend := LongWeekend{
+			StartDate: span[start].date.Format(DateLayout),
+			EndDate:   span[end].date.Format(DateLayout),
+			DayCount:  end - start + 1,
+		}
+		if bridge >= 0 {
+			weekend.NeedBridgeDay = true
+			weekend.BridgeDays = []string{span[bridge].date.Format(DateLayout)}
+		}
+		if seen[weekend.StartDate+weekend.EndDate] {
+			return
+		}
+		seen[weekend.StartDate+weekend.EndDate] = true
+		for _, day := range span[start : end+1] {
+			weekend.Holidays = append(weekend.Holidays, day.holidays...)
+		}
+		weekends = append(weekends, weekend)
+	}
+
+	for i, r := range runs {
+		if !hasHolidayIn(span[r.start:r.end+1], year) {
+			continue
+		}
+		if r.end-r.start+1 >= 3 {
+			add(r.start, r.end, -1)
+			continue
+		}
+
+		// Bridge a single working day to the previous or next run, keeping
+		// whichever gives the longer break
+		start, end, bridge := r.start, r.end, -1
+		if i > 0 && runs[i-1].end == r.start-2 {
+			start, bridge = runs[i-1].start, r.start-1
+		}
+		if i+1 < len(runs) && runs[i+1].start == r.end+2 {
+			if next := runs[i+1].end - r.start + 1; bridge < 0 || next > end-start+1 {
+				start, end, bridge = r.start, runs[i+1].end, r.end+1
+			}
+		}
+		if bridge >= 0 && end-start+1 >= 3 {
+			add(start, end, bridge)
+		}
+	}
+
+	sort.Slice(weekends, func(i, j int) bool {
+		return weekends[i].StartDate < weekends[j].StartDate
+	})
+	return weekends, nil
+}
+
+// hasHolidayIn reports whether any of days is a holiday in year
+func hasHolidayIn(days []dayOff, year int) bool {
+	for _, day := range days {
+		if len(day.holidays) > 0 && day.date.Year() == year {
+			return true
+		}
+	}
+	return false
+}
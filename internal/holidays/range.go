@@ -0,0 +1,97 @@
+package holidays
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// GetHolidaysForYears fetches several years for each country and merges
+// them into a single result per country, in the order of countryCodes.
+// A country's result carries an error if any of its years failed.
+func GetHolidaysForYears(ctx context.Context, service Service, years []int, countryCodes []string) []CountryResult {
+	byYear := make([]map[int]CountryResult, len(countryCodes))
+	for i := range byYear {
+		byYear[i] = make(map[int]CountryResult, len(years))
+	}
+
+	for _, year := range years {
+		results := service.GetHolidaysForCountries(ctx, year, countryCodes)
+		for i, result := range results {
+			if i >= len(byYear) {
+				break
+			}
+			byYear[i][year] = result
+		}
+	}
+
+	merged := make([]CountryResult, len(countryCodes))
+	for i, code := range countryCodes {
+		merged[i] = mergeYears(code, years, byYear[i])
+	}
+	return merged
+}
+
+// mergeYears combines a country's results for each of years, in the order
+// of years. Any failed year fails the merged result.
+func mergeYears(code string, years []int, byYear map[int]CountryResult) CountryResult {
+	merged := CountryResult{CountryCode: code}
+	var (
+		errs    []string
+		wrapped []error
+	)
+	for _, year := range years {
+		result := byYear[year]
+		if result.Error != "" {
+			errs = append(errs, fmt.Sprintf("%d: %s", year, result.Error))
+			if result.Err != nil {
+				wrapped = append(wrapped, fmt.Errorf("%d: %w", year, result.Err))
+			}
+			continue
+		}
+		merged.Holidays = append(merged.Holidays, result.Holidays...)
+		if merged.Provider == "" {
+			merged.Provider = result.Provider
+		}
+		merged.Stale = merged.Stale || result.Stale
+	}
+
+	if len(errs) > 0 {
+		merged.Holidays = nil
+		merged.Error = strings.Join(errs, "; ")
+		merged.Err = errors.Join(wrapped...)
+	}
+	return merged
+}
+
+// GetHolidaysForRange fetches every year touched by the inclusive range
+// from..to and keeps only the holidays inside it
+func GetHolidaysForRange(ctx context.Context, service Service, from, to time.Time, countryCodes []string) []CountryResult {
+	return InRange(GetHolidaysForYears(ctx, service, YearsBetween(from, to), countryCodes), from, to)
+}
+
+// InRange keeps only the holidays inside the inclusive range from..to
+func InRange(results []CountryResult, from, to time.Time) []CountryResult {
+	first, last := from.Format(DateLayout), to.Format(DateLayout)
+	for i := range results {
+		var inRange []Holiday
+		for _, holiday := range results[i].Holidays {
+			if holiday.Date >= first && holiday.Date <= last {
+				inRange = append(inRange, holiday)
+			}
+		}
+		results[i].Holidays = inRange
+	}
+	return results
+}
+
+// YearsBetween lists the years from the year of from to the year of to
+func YearsBetween(from, to time.Time) []int {
+	var years []int
+	for year := from.Year(); year <= to.Year(); year++ {
+		years = append(years, year)
+	}
+	return years
+}
@@ -0,0 +1,53 @@
+package holidays
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestGetHolidaysForRange(t *testing.T) {
+	service := NewService(NewRuleClient(DefaultRules))
+
+	results := GetHolidaysForRange(context.Background(), service, date("2025-12-01"), date("2026-01-31"), []string{"DE", "US"})
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(results))
+	}
+
+	tests := []struct {
+		country string
+		dates   []string
+	}{
+		{"DE", []string{"2025-12-25", "2025-12-26", "2026-01-01", "2026-01-06"}},
+		{"US", []string{"2025-12-25", "2026-01-01", "2026-01-19"}},
+	}
+
+	for i, tt := range tests {
+		result := results[i]
+		if result.CountryCode != tt.country || result.Error != "" {
+			t.Errorf("Unexpected result: %+v", result)
+			continue
+		}
+		if len(result.Holidays) != len(tt.dates) {
+			t.Errorf("Expected %d holidays for %s, got %d", len(tt.dates), tt.country, len(result.Holidays))
+			continue
+		}
+		for j, holiday := range result.Holidays {
+			if holiday.Date != tt.dates[j] {
+				t.Errorf("Expected %s on %s, got %s", tt.country, tt.dates[j], holiday.Date)
+			}
+		}
+	}
+}
+
+func TestGetHolidaysForYearsReportsErrors(t *testing.T) {
+	service := NewService(&mockClient{err: errors.New("upstream down")})
+
+	results := GetHolidaysForYears(context.Background(), service, []int{2025, 2026}, []string{"DE"})
+	if len(results) != 1 {
+		t.Fatalf("Expected 1 result, got %d", len(results))
+	}
+	if want := "2025: upstream down; 2026: upstream down"; results[0].Error != want {
+		t.Errorf("Expected error %q, got %q", want, results[0].Error)
+	}
+}
@@ -0,0 +1,106 @@
+package holidays
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how HTTPClient retries transient failures
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first
+	MaxAttempts int
+	// InitialBackoff is the wait before the first retry; it doubles on each
+	// further retry up to MaxBackoff
+	InitialBackoff time.Duration
+	// MaxBackoff caps the wait between attempts. A Retry-After longer than
+	// this is not waited for and the error is returned instead.
+	MaxBackoff time.Duration
+}
+
+// DefaultRetryPolicy is used by clients created with NewClient
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    3,
+	InitialBackoff: 500 * time.Millisecond,
+	MaxBackoff:     5 * time.Second,
+}
+
+// StatusError is returned when the API answers with an unexpected status
+type StatusError struct {
+	StatusCode int
+	// RetryAfter is the wait requested by the server, if any
+	RetryAfter time.Duration
+}
+
+func (e *StatusError) Error() string {
+	if e.StatusCode == http.StatusNotFound {
+		return fmt.Sprintf("API returned status %d: unknown country or year", e.StatusCode)
+	}
+	return fmt.Sprintf("API returned status %d", e.StatusCode)
+}
+
+// Temporary reports whether the request may succeed if repeated later
+func (e *StatusError) Temporary() bool {
+	switch e.StatusCode {
+	case http.StatusRequestTimeout, http.StatusTooManyRequests:
+		return true
+	}
+	return e.StatusCode >= 500
+}
+
+// Unwrap lets a 404 match ErrNotFound
+func (e *StatusError) Unwrap() error {
+	if e.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// ErrNotFound is returned when a provider has no data for a country and year
+var ErrNotFound = errors.New("holidays not found")
+
+// ErrMalformedResponse is returned when a provider answers successfully but
+// its body cannot be decoded. Repeating the request would return the same
+// body, so it is not retried; unlike a permanent error it still counts
+// against the provider's health and lets a saved copy be served instead.
+var ErrMalformedResponse = errors.New("malformed response")
+
+// IsPermanent reports whether err is a failure that retrying will not fix,
+// such as a 404 for an unknown country
+func IsPermanent(err error) bool {
+	if errors.Is(err, ErrNotFound) {
+		return true
+	}
+	var statusErr *StatusError
+	return errors.As(err, &statusErr) && !statusErr.Temporary()
+}
+
+// backoff returns the wait before retry number n, starting at 1
+func (p RetryPolicy) backoff(n int) time.Duration {
+	wait := p.InitialBackoff
+	for i := 1; i < n && wait < p.MaxBackoff; i++ {
+		wait *= 2
+	}
+	if wait > p.MaxBackoff {
+		wait = p.MaxBackoff
+	}
+	return wait
+}
+
+// parseRetryAfter reads a Retry-After header given in seconds or as an HTTP date
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if wait := time.Until(date); wait > 0 {
+			return wait
+		}
+	}
+	return 0
+}
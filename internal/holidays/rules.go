@@ -0,0 +1,304 @@
+package holidays
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DateRule computes the date a holiday falls on in a given year
+type DateRule interface {
+	// date returns the holiday's date, or false if it does not occur that year
+	date(year int) (time.Time, bool)
+	// fixed reports whether the holiday is on the same day every year
+	fixed() bool
+}
+
+// ObservedPolicy moves a holiday falling on a weekend to a working day
+type ObservedPolicy int
+
+const (
+	// ObserveActual keeps the holiday on its actual date
+	ObserveActual ObservedPolicy = iota
+	// ObserveNearestWeekday moves Saturday to Friday and Sunday to Monday
+	ObserveNearestWeekday
+	// ObserveNextMonday moves Saturday and Sunday to the following Monday
+	ObserveNextMonday
+	// ObserveNextWorkday moves a weekend holiday to the next weekday that is
+	// not already a holiday, as with the UK's Christmas and Boxing Day
+	ObserveNextWorkday
+	// ObservePreviousSaturday moves Sunday to the Saturday before, as with
+	// King's Day in the Netherlands
+	ObservePreviousSaturday
+)
+
+// Rule describes how to compute one holiday of a country
+type Rule struct {
+	Name      string
+	LocalName string
+	Date      DateRule
+	Observed  ObservedPolicy
+	// Types defaults to Public
+	Types []string
+	// Counties limits the holiday to subdivisions; empty means nationwide
+	Counties []string
+	// From and To are the first and last years the holiday applies to;
+	// zero leaves that end open
+	From int
+	To   int
+}
+
+// activeIn reports whether the rule applies to year
+func (r Rule) activeIn(year int) bool {
+	return (r.From == 0 || year >= r.From) && (r.To == 0 || year <= r.To)
+}
+
+// RuleClient computes holidays from rules instead of asking an upstream.
+// The rules are meant for the years 1900–2100 that the handlers accept;
+// years before 1 give wrong dates.
+type RuleClient struct {
+	rules map[string][]Rule
+}
+
+// NewRuleClient creates a client from rules keyed by country code
+func NewRuleClient(rules map[string][]Rule) *RuleClient {
+	normalized := make(map[string][]Rule, len(rules))
+	for country, countryRules := range rules {
+		normalized[strings.ToUpper(country)] = countryRules
+	}
+	return &RuleClient{rules: normalized}
+}
+
+// GetHolidays computes the holidays of a country that fall in year, or
+// returns ErrNotFound if there are no rules for the country. A holiday
+// observed across New Year, such as New Year's Day moved back to 31
+// December, belongs to the year it falls in.
+func (c *RuleClient) GetHolidays(ctx context.Context, year int, countryCode string) ([]Holiday, error) {
+	country := strings.ToUpper(countryCode)
+	rules, ok := c.rules[country]
+	if !ok {
+		return nil, fmt.Errorf("no holiday rules for %s: %w", countryCode, ErrNotFound)
+	}
+
+	var holidays []Holiday
+	for ruleYear := year - 1; ruleYear <= year+1; ruleYear++ {
+		for _, holiday := range computeHolidays(rules, ruleYear, country) {
+			if y, err := holidayYear(holiday.Date); err == nil && y == year {
+				holidays = append(holidays, holiday)
+			}
+		}
+	}
+	sort.SliceStable(holidays, func(i, j int) bool {
+		return holidays[i].Date < holidays[j].Date
+	})
+	return holidays, nil
+}
+
+// computeHolidays applies rules to year. Holidays on their actual date are
+// placed first so that those moved off a weekend can avoid them.
+func computeHolidays(rules []Rule, year int, country string) []Holiday {
+	type placed struct {
+		rule Rule
+		date time.Time
+	}
+
+	var actual, shifted []placed
+	taken := make(map[time.Time]bool)
+	for _, rule := range rules {
+		if !rule.activeIn(year) {
+			continue
+		}
+		date, ok := rule.Date.date(year)
+		if !ok {
+			continue
+		}
+		if rule.Observed != ObserveActual && isWeekend(date) {
+			shifted = append(shifted, placed{rule, date})
+			continue
+		}
+		actual = append(actual, placed{rule, date})
+		taken[date] = true
+	}
+
+	for i, p := range shifted {
+		shifted[i].date = observe(p.date, p.rule.Observed, taken)
+		taken[shifted[i].date] = true
+	}
+
+	holidays := make([]Holiday, 0, len(actual)+len(shifted))
+	for _, p := range append(actual, shifted...) {
+		types := p.rule.Types
+		if len(types) == 0 {
+			types = []string{"Public"}
+		}
+		holidays = append(holidays, Holiday{
+			Date:        p.date.Format("2006-01-02"),
+			LocalName:   p.rule.LocalName,
+			Name:        p.rule.Name,
+			CountryCode: country,
+			Fixed:       p.rule.Date.fixed() && p.rule.Observed == ObserveActual,
+			Global:      len(p.rule.Counties) == 0,
+			Counties:    append([]string(nil), p.rule.Counties...),
+			Types:       append([]string(nil), types...),
+		})
+	}
+
+	sort.SliceStable(holidays, func(i, j int) bool {
+		return holidays[i].Date < holidays[j].Date
+	})
+	return holidays
+}
+
+// observe moves a weekend date according to policy
+func observe(date time.Time, policy ObservedPolicy, taken map[time.Time]bool) time.Time {
+	switch policy {
+	case ObserveNearestWeekday:
+		if date.Weekday() == time.Saturday {
+			return date.AddDate(0, 0, -1)
+		}
+		return date.AddDate(0, 0, 1)
+	case ObserveNextMonday:
+		return date.AddDate(0, 0, (8-int(date.Weekday()))%7)
+	case ObserveNextWorkday:
+		for isWeekend(date) || taken[date] {
+			date = date.AddDate(0, 0, 1)
+		}
+	case ObservePreviousSaturday:
+		if date.Weekday() == time.Sunday {
+			return date.AddDate(0, 0, -1)
+		}
+	}
+	return date
+}
+
+func isWeekend(date time.Time) bool {
+	return date.Weekday() == time.Saturday || date.Weekday() == time.Sunday
+}
+
+type fixedDate struct {
+	month time.Month
+	day   int
+}
+
+// FixedDate is a holiday on the same month and day every year
+func FixedDate(month time.Month, day int) DateRule {
+	return fixedDate{month, day}
+}
+
+func (r fixedDate) date(year int) (time.Time, bool) {
+	date := time.Date(year, r.month, r.day, 0, 0, 0, 0, time.UTC)
+	// February 29th only exists in leap years
+	return date, date.Month() == r.month
+}
+
+func (r fixedDate) fixed() bool { return true }
+
+type nthWeekday struct {
+	n       int
+	weekday time.Weekday
+	month   time.Month
+}
+
+// NthWeekday is the nth weekday of a month, e.g. the 4th Thursday of
+// November. A negative n counts from the end of the month.
+func NthWeekday(n int, weekday time.Weekday, month time.Month) DateRule {
+	return nthWeekday{n, weekday, month}
+}
+
+// LastWeekday is the last weekday of a month, e.g. the last Monday of May
+func LastWeekday(weekday time.Weekday, month time.Month) DateRule {
+	return nthWeekday{-1, weekday, month}
+}
+
+func (r nthWeekday) date(year int) (time.Time, bool) {
+	var date time.Time
+	if r.n > 0 {
+		first := time.Date(year, r.month, 1, 0, 0, 0, 0, time.UTC)
+		date = first.AddDate(0, 0, (int(r.weekday)-int(first.Weekday())+7)%7+7*(r.n-1))
+	} else {
+		last := time.Date(year, r.month+1, 0, 0, 0, 0, 0, time.UTC)
+		date = last.AddDate(0, 0, -((int(last.Weekday())-int(r.weekday)+7)%7)+7*(r.n+1))
+	}
+	return date, r.n != 0 && date.Month() == r.month
+}
+
+func (r nthWeekday) fixed() bool { return false }
+
+type weekdayBefore struct {
+	weekday time.Weekday
+	month   time.Month
+	day     int
+}
+
+// WeekdayOnOrBefore is the last given weekday on or before a date, e.g.
+// Canada's Victoria Day, the Monday on or before May 24th
+func WeekdayOnOrBefore(weekday time.Weekday, month time.Month, day int) DateRule {
+	return weekdayBefore{weekday, month, day}
+}
+
+func (r weekdayBefore) date(year int) (time.Time, bool) {
+	date := time.Date(year, r.month, r.day, 0, 0, 0, 0, time.UTC)
+	return date.AddDate(0, 0, -((int(date.Weekday()) - int(r.weekday) + 7) % 7)), true
+}
+
+func (r weekdayBefore) fixed() bool { return false }
+
+type easterOffset struct {
+	days     int
+	orthodox bool
+}
+
+// EasterOffset is a number of days after Western Easter Sunday, e.g. -2
+// for Good Friday or 39 for Ascension Day
+func EasterOffset(days int) DateRule {
+	return easterOffset{days: days}
+}
+
+// OrthodoxEasterOffset is a number of days after Orthodox Easter Sunday
+func OrthodoxEasterOffset(days int) DateRule {
+	return easterOffset{days: days, orthodox: true}
+}
+
+func (r easterOffset) date(year int) (time.Time, bool) {
+	easter := WesternEaster(year)
+	if r.orthodox {
+		easter = OrthodoxEaster(year)
+	}
+	return easter.AddDate(0, 0, r.days), true
+}
+
+func (r easterOffset) fixed() bool { return false }
+
+// WesternEaster returns Easter Sunday in the Gregorian calendar, using the
+// anonymous Gregorian algorithm. It relies on truncating division and so
+// only holds for positive years; the API uses it for 1900–2100.
+func WesternEaster(year int) time.Time {
+	a := year % 19
+	b, c := year/100, year%100
+	d, e := b/4, b%4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i, k := c/4, c%4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+}
+
+// OrthodoxEaster returns Orthodox Easter Sunday as a Gregorian date. It is
+// computed in the Julian calendar with Meeus' algorithm and then shifted by
+// the difference between the calendars in that century. Like WesternEaster
+// it only holds for positive years.
+func OrthodoxEaster(year int) time.Time {
+	a, b, c := year%4, year%7, year%19
+	d := (19*c + 15) % 30
+	e := (2*a + 4*b - d + 34) % 7
+	month := (d + e + 114) / 31
+	day := (d+e+114)%31 + 1
+	julianOffset := year/100 - year/400 - 2
+	return time.Date(year, time.Month(month), day+julianOffset, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,147 @@
+package holidays
+
+import "time"
+
+// DefaultRules are the public holidays of a few major shipping countries,
+// including one that follows the Orthodox Easter. Regional holidays are
+// included for the countries of the embedded dataset, and UK bank holidays
+// carry the Bank type.
+var DefaultRules = map[string][]Rule{
+	"US": {
+		{Name: "New Year's Day", LocalName: "New Year's Day", Date: FixedDate(time.January, 1), Observed: ObserveNearestWeekday},
+		{Name: "Martin Luther King, Jr. Day", LocalName: "Martin Luther King, Jr. Day", Date: NthWeekday(3, time.Monday, time.January), From: 1986},
+		{Name: "Washington's Birthday", LocalName: "Presidents Day", Date: NthWeekday(3, time.Monday, time.February)},
+		{Name: "Memorial Day", LocalName: "Memorial Day", Date: LastWeekday(time.Monday, time.May)},
+		{Name: "Juneteenth National Independence Day", LocalName: "Juneteenth National Independence Day", Date: FixedDate(time.June, 19), Observed: ObserveNearestWeekday, From: 2021},
+		{Name: "Independence Day", LocalName: "Independence Day", Date: FixedDate(time.July, 4), Observed: ObserveNearestWeekday},
+		{Name: "Labour Day", LocalName: "Labor Day", Date: NthWeekday(1, time.Monday, time.September)},
+		{Name: "Columbus Day", LocalName: "Columbus Day", Date: NthWeekday(2, time.Monday, time.October), Counties: []string{
+			"US-AL", "US-AZ", "US-CO", "US-CT", "US-DC", "US-GA", "US-ID", "US-IL", "US-IN", "US-IA", "US-KS", "US-KY",
+			"US-LA", "US-ME", "US-MD", "US-MA", "US-MS", "US-MO", "US-MT", "US-NE", "US-NH", "US-NJ", "US-NY", "US-NC",
+			"US-OH", "US-OK", "US-PA", "US-RI", "US-SC", "US-TN", "US-UT", "US-VA", "US-WV",
+		}},
+		{Name: "Veterans Day", LocalName: "Veterans Day", Date: FixedDate(time.November, 11), Observed: ObserveNearestWeekday},
+		{Name: "Thanksgiving Day", LocalName: "Thanksgiving Day", Date: NthWeekday(4, time.Thursday, time.November)},
+		{Name: "Christmas Day", LocalName: "Christmas Day", Date: FixedDate(time.December, 25), Observed: ObserveNearestWeekday},
+	},
+	"CA": {
+		{Name: "New Year's Day", LocalName: "New Year's Day", Date: FixedDate(time.January, 1)},
+		{Name: "Family Day", LocalName: "Family Day", Date: NthWeekday(3, time.Monday, time.February), Counties: []string{"CA-AB", "CA-ON", "CA-SK"}, From: 2008, To: 2012},
+		{Name: "Family Day", LocalName: "Family Day", Date: NthWeekday(3, time.Monday, time.February), Counties: []string{"CA-AB", "CA-BC", "CA-ON", "CA-SK"}, From: 2013, To: 2017},
+		{Name: "Family Day", LocalName: "Family Day", Date: NthWeekday(3, time.Monday, time.February), Counties: []string{"CA-AB", "CA-BC", "CA-NB", "CA-ON", "CA-SK"}, From: 2018},
+		{Name: "Louis Riel Day", LocalName: "Louis Riel Day", Date: NthWeekday(3, time.Monday, time.February), Counties: []string{"CA-MB"}, From: 2008},
+		{Name: "Islander Day", LocalName: "Islander Day", Date: NthWeekday(3, time.Monday, time.February), Counties: []string{"CA-PE"}, From: 2010},
+		{Name: "Heritage Day", LocalName: "Heritage Day", Date: NthWeekday(3, time.Monday, time.February), Counties: []string{"CA-NS"}, From: 2015},
+		{Name: "Good Friday", LocalName: "Good Friday", Date: EasterOffset(-2)},
+		{Name: "Victoria Day", LocalName: "Victoria Day", Date: WeekdayOnOrBefore(time.Monday, time.May, 24), Counties: []string{"CA-AB", "CA-BC", "CA-MB", "CA-NS", "CA-NT", "CA-NU", "CA-ON", "CA-SK", "CA-YT"}},
+		{Name: "National Patriots' Day", LocalName: "Journée nationale des patriotes", Date: WeekdayOnOrBefore(time.Monday, time.May, 24), Counties: []string{"CA-QC"}, From: 2003},
+		{Name: "Saint-Jean-Baptiste Day", LocalName: "Fête nationale du Québec", Date: FixedDate(time.June, 24), Counties: []string{"CA-QC"}},
+		{Name: "Canada Day", LocalName: "Canada Day", Date: FixedDate(time.July, 1)},
+		{Name: "Civic Holiday", LocalName: "Civic Holiday", Date: NthWeekday(1, time.Monday, time.August), Counties: []string{"CA-MB", "CA-NT", "CA-NU", "CA-ON"}},
+		{Name: "British Columbia Day", LocalName: "British Columbia Day", Date: NthWeekday(1, time.Monday, time.August), Counties: []string{"CA-BC"}},
+		{Name: "New Brunswick Day", LocalName: "New Brunswick Day", Date: NthWeekday(1, time.Monday, time.August), Counties: []string{"CA-NB"}},
+		{Name: "Saskatchewan Day", LocalName: "Saskatchewan Day", Date: NthWeekday(1, time.Monday, time.August), Counties: []string{"CA-SK"}},
+		{Name: "Labour Day", LocalName: "Labour Day", Date: NthWeekday(1, time.Monday, time.September)},
+		{Name: "Thanksgiving", LocalName: "Thanksgiving", Date: NthWeekday(2, time.Monday, time.October), Counties: []string{"CA-AB", "CA-BC", "CA-MB", "CA-NT", "CA-NU", "CA-ON", "CA-QC", "CA-SK", "CA-YT"}},
+		{Name: "Remembrance Day", LocalName: "Remembrance Day", Date: FixedDate(time.November, 11), Counties: []string{"CA-AB", "CA-BC", "CA-NB", "CA-NL", "CA-NT", "CA-NU", "CA-PE", "CA-SK", "CA-YT"}},
+		{Name: "Christmas Day", LocalName: "Christmas Day", Date: FixedDate(time.December, 25)},
+		{Name: "St. Stephen's Day", LocalName: "Boxing Day", Date: FixedDate(time.December, 26), Counties: []string{"CA-ON"}},
+	},
+	"GB": {
+		{Name: "New Year's Day", LocalName: "New Year's Day", Date: FixedDate(time.January, 1), Observed: ObserveNextWorkday, Types: []string{"Public", "Bank"}},
+		{Name: "Saint Patrick's Day", LocalName: "Saint Patrick's Day", Date: FixedDate(time.March, 17), Observed: ObserveNextWorkday, Types: []string{"Bank"}, Counties: []string{"GB-NIR"}},
+		{Name: "Good Friday", LocalName: "Good Friday", Date: EasterOffset(-2)},
+		{Name: "Easter Monday", LocalName: "Easter Monday", Date: EasterOffset(1), Types: []string{"Public", "Bank"}, Counties: []string{"GB-ENG", "GB-WLS", "GB-NIR"}},
+		{Name: "Early May Bank Holiday", LocalName: "Early May Bank Holiday", Date: NthWeekday(1, time.Monday, time.May), Types: []string{"Bank"}},
+		{Name: "Spring Bank Holiday", LocalName: "Spring Bank Holiday", Date: LastWeekday(time.Monday, time.May), Types: []string{"Bank"}},
+		{Name: "Battle of the Boyne", LocalName: "Orangemen's Day", Date: FixedDate(time.July, 12), Observed: ObserveNextWorkday, Types: []string{"Bank"}, Counties: []string{"GB-NIR"}},
+		{Name: "Summer Bank Holiday", LocalName: "Summer Bank Holiday", Date: NthWeekday(1, time.Monday, time.August), Types: []string{"Bank"}, Counties: []string{"GB-SCT"}},
+		{Name: "Summer Bank Holiday", LocalName: "Summer Bank Holiday", Date: LastWeekday(time.Monday, time.August), Types: []string{"Bank"}, Counties: []string{"GB-ENG", "GB-WLS", "GB-NIR"}},
+		{Name: "Saint Andrew's Day", LocalName: "Saint Andrew's Day", Date: FixedDate(time.November, 30), Observed: ObserveNextMonday, Types: []string{"Bank"}, Counties: []string{"GB-SCT"}},
+		{Name: "Christmas Day", LocalName: "Christmas Day", Date: FixedDate(time.December, 25), Observed: ObserveNextWorkday},
+		{Name: "St. Stephen's Day", LocalName: "Boxing Day", Date: FixedDate(time.December, 26), Observed: ObserveNextWorkday, Types: []string{"Public", "Bank"}},
+	},
+	"DE": {
+		{Name: "New Year's Day", LocalName: "Neujahr", Date: FixedDate(time.January, 1)},
+		{Name: "Epiphany", LocalName: "Heilige Drei Könige", Date: FixedDate(time.January, 6), Counties: []string{"DE-BW", "DE-BY", "DE-ST"}},
+		{Name: "International Women's Day", LocalName: "Frauentag", Date: FixedDate(time.March, 8), Counties: []string{"DE-BE"}, From: 2019, To: 2022},
+		{Name: "International Women's Day", LocalName: "Frauentag", Date: FixedDate(time.March, 8), Counties: []string{"DE-BE", "DE-MV"}, From: 2023},
+		{Name: "Good Friday", LocalName: "Karfreitag", Date: EasterOffset(-2)},
+		{Name: "Easter Sunday", LocalName: "Ostersonntag", Date: EasterOffset(0), Counties: []string{"DE-BB"}},
+		{Name: "Easter Monday", LocalName: "Ostermontag", Date: EasterOffset(1)},
+		{Name: "Labour Day", LocalName: "Tag der Arbeit", Date: FixedDate(time.May, 1)},
+		{Name: "Ascension Day", LocalName: "Christi Himmelfahrt", Date: EasterOffset(39)},
+		{Name: "Pentecost", LocalName: "Pfingstsonntag", Date: EasterOffset(49), Counties: []string{"DE-BB"}},
+		{Name: "Whit Monday", LocalName: "Pfingstmontag", Date: EasterOffset(50)},
+		{Name: "Assumption Day", LocalName: "Mariä Himmelfahrt", Date: FixedDate(time.August, 15), Counties: []string{"DE-SL"}},
+		{Name: "World Children's Day", LocalName: "Weltkindertag", Date: FixedDate(time.September, 20), Counties: []string{"DE-TH"}, From: 2019},
+		{Name: "German Unity Day", LocalName: "Tag der Deutschen Einheit", Date: FixedDate(time.October, 3), From: 1990},
+		{Name: "Corpus Christi", LocalName: "Fronleichnam", Date: EasterOffset(60), Counties: []string{"DE-BW", "DE-BY", "DE-HE", "DE-NW", "DE-RP", "DE-SL"}},
+		{Name: "Reformation Day", LocalName: "Reformationstag", Date: FixedDate(time.October, 31), Counties: []string{"DE-BB", "DE-MV", "DE-SN", "DE-ST", "DE-TH"}, From: 1990, To: 2017},
+		{Name: "Reformation Day", LocalName: "Reformationstag", Date: FixedDate(time.October, 31), Counties: []string{"DE-BB", "DE-HB", "DE-HH", "DE-MV", "DE-NI", "DE-SH", "DE-SN", "DE-ST", "DE-TH"}, From: 2018},
+		{Name: "All Saints' Day", LocalName: "Allerheiligen", Date: FixedDate(time.November, 1), Counties: []string{"DE-BW", "DE-BY", "DE-NW", "DE-RP", "DE-SL"}},
+		{Name: "Repentance and Prayer Day", LocalName: "Buß- und Bettag", Date: WeekdayOnOrBefore(time.Wednesday, time.November, 22), Counties: []string{"DE-SN"}, From: 1995},
+		{Name: "Christmas Day", LocalName: "Erster Weihnachtstag", Date: FixedDate(time.December, 25)},
+		{Name: "St. Stephen's Day", LocalName: "Zweiter Weihnachtstag", Date: FixedDate(time.December, 26)},
+	},
+	"FR": {
+		{Name: "New Year's Day", LocalName: "Jour de l'an", Date: FixedDate(time.January, 1)},
+		{Name: "Good Friday", LocalName: "Vendredi saint", Date: EasterOffset(-2), Counties: []string{"FR-57", "FR-67", "FR-68"}},
+		{Name: "Easter Monday", LocalName: "Lundi de Pâques", Date: EasterOffset(1)},
+		{Name: "Labour Day", LocalName: "Fête du Travail", Date: FixedDate(time.May, 1)},
+		{Name: "Victory in Europe Day", LocalName: "Victoire 1945", Date: FixedDate(time.May, 8)},
+		{Name: "Ascension Day", LocalName: "Ascension", Date: EasterOffset(39)},
+		{Name: "Whit Monday", LocalName: "Lundi de Pentecôte", Date: EasterOffset(50)},
+		{Name: "Bastille Day", LocalName: "Fête nationale", Date: FixedDate(time.July, 14)},
+		{Name: "Assumption Day", LocalName: "Assomption", Date: FixedDate(time.August, 15)},
+		{Name: "All Saints' Day", LocalName: "Toussaint", Date: FixedDate(time.November, 1)},
+		{Name: "Armistice Day", LocalName: "Armistice 1918", Date: FixedDate(time.November, 11)},
+		{Name: "Christmas Day", LocalName: "Noël", Date: FixedDate(time.December, 25)},
+		{Name: "St. Stephen's Day", LocalName: "Saint-Étienne", Date: FixedDate(time.December, 26), Counties: []string{"FR-57", "FR-67", "FR-68"}},
+	},
+	"IT": {
+		{Name: "New Year's Day", LocalName: "Capodanno", Date: FixedDate(time.January, 1)},
+		{Name: "Epiphany", LocalName: "Epifania", Date: FixedDate(time.January, 6), To: 1976},
+		{Name: "Epiphany", LocalName: "Epifania", Date: FixedDate(time.January, 6), From: 1986},
+		{Name: "Easter Sunday", LocalName: "Pasqua", Date: EasterOffset(0)},
+		{Name: "Easter Monday", LocalName: "Lunedì dell'Angelo", Date: EasterOffset(1)},
+		{Name: "Liberation Day", LocalName: "Festa della Liberazione", Date: FixedDate(time.April, 25), From: 1946},
+		{Name: "Labour Day", LocalName: "Festa del Lavoro", Date: FixedDate(time.May, 1)},
+		{Name: "Whit Monday", LocalName: "Lunedì di Pentecoste", Date: EasterOffset(50), Counties: []string{"IT-BZ"}},
+		{Name: "Republic Day", LocalName: "Festa della Repubblica", Date: FixedDate(time.June, 2), From: 1947, To: 1976},
+		{Name: "Republic Day", LocalName: "Festa della Repubblica", Date: FixedDate(time.June, 2), From: 2001},
+		{Name: "Assumption Day", LocalName: "Ferragosto", Date: FixedDate(time.August, 15)},
+		{Name: "All Saints' Day", LocalName: "Ognissanti", Date: FixedDate(time.November, 1)},
+		{Name: "Immaculate Conception", LocalName: "Immacolata Concezione", Date: FixedDate(time.December, 8)},
+		{Name: "Christmas Day", LocalName: "Natale", Date: FixedDate(time.December, 25)},
+		{Name: "St. Stephen's Day", LocalName: "Santo Stefano", Date: FixedDate(time.December, 26)},
+	},
+	"NL": {
+		{Name: "New Year's Day", LocalName: "Nieuwjaarsdag", Date: FixedDate(time.January, 1)},
+		{Name: "Good Friday", LocalName: "Goede Vrijdag", Date: EasterOffset(-2)},
+		{Name: "Easter Sunday", LocalName: "Eerste Paasdag", Date: EasterOffset(0)},
+		{Name: "Easter Monday", LocalName: "Tweede Paasdag", Date: EasterOffset(1)},
+		{Name: "King's Day", LocalName: "Koningsdag", Date: FixedDate(time.April, 27), Observed: ObservePreviousSaturday, From: 2014},
+		{Name: "Liberation Day", LocalName: "Bevrijdingsdag", Date: FixedDate(time.May, 5)},
+		{Name: "Ascension Day", LocalName: "Hemelvaartsdag", Date: EasterOffset(39)},
+		{Name: "Pentecost", LocalName: "Eerste Pinksterdag", Date: EasterOffset(49)},
+		{Name: "Whit Monday", LocalName: "Tweede Pinksterdag", Date: EasterOffset(50)},
+		{Name: "Christmas Day", LocalName: "Eerste Kerstdag", Date: FixedDate(time.December, 25)},
+		{Name: "St. Stephen's Day", LocalName: "Tweede Kerstdag", Date: FixedDate(time.December, 26)},
+	},
+	"GR": {
+		{Name: "New Year's Day", LocalName: "Πρωτοχρονιά", Date: FixedDate(time.January, 1)},
+		{Name: "Epiphany", LocalName: "Θεοφάνεια", Date: FixedDate(time.January, 6)},
+		{Name: "Clean Monday", LocalName: "Καθαρά Δευτέρα", Date: OrthodoxEasterOffset(-48)},
+		{Name: "Independence Day", LocalName: "Εικοστή Πέμπτη Μαρτίου", Date: FixedDate(time.March, 25)},
+		{Name: "Good Friday", LocalName: "Μεγάλη Παρασκευή", Date: OrthodoxEasterOffset(-2)},
+		{Name: "Easter Sunday", LocalName: "Κυριακή του Πάσχα", Date: OrthodoxEasterOffset(0)},
+		{Name: "Easter Monday", LocalName: "Δευτέρα του Πάσχα", Date: OrthodoxEasterOffset(1)},
+		{Name: "Labour Day", LocalName: "Πρωτομαγιά", Date: FixedDate(time.May, 1)},
+		{Name: "Whit Monday", LocalName: "Αγίου Πνεύματος", Date: OrthodoxEasterOffset(50)},
+		{Name: "Assumption Day", LocalName: "Κοίμηση της Θεοτόκου", Date: FixedDate(time.August, 15)},
+		{Name: "Ochi Day", LocalName: "Το Όχι", Date: FixedDate(time.October, 28)},
+		{Name: "Christmas Day", LocalName: "Χριστούγεννα", Date: FixedDate(time.December, 25)},
+		{Name: "St. Stephen's Day", LocalName: "Σύναξις Υπεραγίας Θεοτόκου Μαρίας", Date: FixedDate(time.December, 26)},
+	},
+}
@@ -0,0 +1,174 @@
+package holidays
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEasterDates(t *testing.T) {
+	tests := []struct {
+		year     int
+		western  string
+		orthodox string
+	}{
+		{1961, "1961-04-02", "1961-04-09"},
+		{2024, "2024-03-31", "2024-05-05"},
+		{2025, "2025-04-20", "2025-04-20"},
+		{2026, "2026-04-05", "2026-04-12"},
+		{2100, "2100-03-28", "2100-05-02"},
+	}
+
+	for _, tt := range tests {
+		if got := WesternEaster(tt.year).Format("2006-01-02"); got != tt.western {
+			t.Errorf("Expected Western Easter %s, got %s", tt.western, got)
+		}
+		if got := OrthodoxEaster(tt.year).Format("2006-01-02"); got != tt.orthodox {
+			t.Errorf("Expected Orthodox Easter %s, got %s", tt.orthodox, got)
+		}
+	}
+}
+
+func TestRuleClientComputesRules(t *testing.T) {
+	client := NewRuleClient(map[string][]Rule{
+		"XX": {
+			{Name: "Fixed", Date: FixedDate(time.July, 4), Observed: ObserveNearestWeekday},
+			{Name: "Leap", Date: FixedDate(time.February, 29)},
+			{Name: "Third Monday", Date: NthWeekday(3, time.Monday, time.January)},
+			{Name: "Last Monday", Date: LastWeekday(time.Monday, time.May)},
+			{Name: "Good Friday", Date: EasterOffset(-2)},
+			{Name: "Old", Date: FixedDate(time.March, 1), To: 2000},
+			{Name: "New", Date: FixedDate(time.March, 2), From: 2021},
+		},
+	})
+
+	tests := []struct {
+		year int
+		want map[string]string
+	}{
+		{2026, map[string]string{"Fixed": "2026-07-03", "Third Monday": "2026-01-19", "Last Monday": "2026-05-25", "Good Friday": "2026-04-03", "New": "2026-03-02"}},
+		{2028, map[string]string{"Fixed": "2028-07-04", "Leap": "2028-02-29", "Third Monday": "2028-01-17", "Last Monday": "2028-05-29", "Good Friday": "2028-04-14", "New": "2028-03-02"}},
+		{1850, map[string]string{"Fixed": "1850-07-04", "Third Monday": "1850-01-21", "Last Monday": "1850-05-27", "Good Friday": "1850-03-29", "Old": "1850-03-01"}},
+		{2500, map[string]string{"Fixed": "2500-07-05", "Third Monday": "2500-01-18", "Last Monday": "2500-05-31", "Good Friday": "2500-04-16", "New": "2500-03-02"}},
+	}
+
+	for _, tt := range tests {
+		holidays, err := client.GetHolidays(context.Background(), tt.year, "xx")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		got := make(map[string]string)
+		for _, holiday := range holidays {
+			got[holiday.Name] = holiday.Date
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("Expected %d holidays in %d, got %v", len(tt.want), tt.year, got)
+		}
+		for name, date := range tt.want {
+			if got[name] != date {
+				t.Errorf("Expected %s on %s in %d, got %q", name, date, tt.year, got[name])
+			}
+		}
+	}
+
+	if _, err := client.GetHolidays(context.Background(), 2026, "YY"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for a country without rules, got %v", err)
+	}
+}
+
+func TestRuleClientShiftsPastOtherHolidays(t *testing.T) {
+	client := NewRuleClient(DefaultRules)
+
+	tests := []struct {
+		year      int
+		christmas string
+		boxingDay string
+	}{
+		{2021, "2021-12-27", "2021-12-28"}, // Saturday and Sunday
+		{2022, "2022-12-27", "2022-12-26"}, // Sunday and Monday
+		{2026, "2026-12-25", "2026-12-28"}, // Friday and Saturday
+	}
+
+	for _, tt := range tests {
+		holidays, _ := client.GetHolidays(context.Background(), tt.year, "GB")
+		for _, holiday := range holidays {
+			if holiday.Name == "Christmas Day" && holiday.Date != tt.christmas {
+				t.Errorf("Expected Christmas Day on %s, got %s", tt.christmas, holiday.Date)
+			}
+			if holiday.LocalName == "Boxing Day" && holiday.Date != tt.boxingDay {
+				t.Errorf("Expected Boxing Day on %s, got %s", tt.boxingDay, holiday.Date)
+			}
+		}
+	}
+}
+
+func TestRuleClientFilesHolidaysByDate(t *testing.T) {
+	client := NewRuleClient(DefaultRules)
+
+	// New Year's Day 2028 is a Saturday and is observed on Friday 31 December
+	in2027, _ := client.GetHolidays(context.Background(), 2027, "US")
+	if last := in2027[len(in2027)-1]; last.Date != "2027-12-31" || last.Name != "New Year's Day" {
+		t.Errorf("Expected New Year's Day on 2027-12-31 last in 2027, got %s on %s", last.Name, last.Date)
+	}
+	in2028, _ := client.GetHolidays(context.Background(), 2028, "US")
+	for _, holiday := range in2028 {
+		if holiday.Date[:4] != "2028" {
+			t.Errorf("Expected only 2028 dates, got %s on %s", holiday.Name, holiday.Date)
+		}
+	}
+}
+
+// ruleDifferences lists holidays, keyed "DE Reformation Day", where DefaultRules
+// knowingly differ from the upstream data in the embedded dataset, with the
+// reason. Anything else that differs fails TestDefaultRulesMatchEmbeddedDataset.
+var ruleDifferences = map[string]string{}
+
+func TestDefaultRulesMatchEmbeddedDataset(t *testing.T) {
+	embedded, err := NewEmbeddedClient()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	rules := NewRuleClient(DefaultRules)
+
+	key := func(holiday Holiday) string {
+		return strings.Join([]string{holiday.Date, holiday.Name, holiday.LocalName, strconv.FormatBool(holiday.Global),
+			strings.Join(holiday.Counties, ","), strings.Join(holiday.Types, ",")}, " | ")
+	}
+
+	for _, country := range []string{"US", "CA", "GB", "DE", "FR", "NL", "IT"} {
+		for year := 2025; year <= 2028; year++ {
+			expected, err := embedded.GetHolidays(context.Background(), year, country)
+			if err != nil {
+				t.Errorf("Unexpected error for %s %d: %v", country, year, err)
+				continue
+			}
+			computed, _ := rules.GetHolidays(context.Background(), year, country)
+
+			want := make(map[string]bool, len(expected))
+			for _, holiday := range expected {
+				want[key(holiday)] = true
+			}
+			got := make(map[string]bool, len(computed))
+			for _, holiday := range computed {
+				got[key(holiday)] = true
+				if _, known := ruleDifferences[country+" "+holiday.Name]; known {
+					continue
+				}
+				if !want[key(holiday)] {
+					t.Errorf("Unexpected holiday computed for %s %d: %s", country, year, key(holiday))
+				}
+			}
+			for _, holiday := range expected {
+				if _, known := ruleDifferences[country+" "+holiday.Name]; known {
+					continue
+				}
+				if !got[key(holiday)] {
+					t.Errorf("Expected rules to compute for %s %d: %s", country, year, key(holiday))
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package holidays
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+)
+
+const (
+	// DefaultBaseURL is the public Nager.Date API
+	DefaultBaseURL = "https://date.nager.at/api/v3"
+	// DefaultTimeout bounds a single request to the API
+	DefaultTimeout = 10 * time.Second
+)
+
+// ClientSettings configures how an HTTPClient reaches the upstream API.
+// Zero values fall back to the defaults used by NewClient.
+type ClientSettings struct {
+	BaseURL   string
+	Timeout   time.Duration
+	UserAgent string
+	// ProxyURL overrides the proxy from the environment
+	ProxyURL string
+	// CAFile adds a PEM bundle of trusted roots, e.g. for a staging mirror
+	CAFile             string
+	InsecureSkipVerify bool
+	Retry              RetryPolicy
+}
+
+// NewConfiguredClient creates a client that follows settings. The function
+// is called before every lookup, and the client is rebuilt whenever the
+// returned settings change, so configuration can be reloaded at runtime.
+func NewConfiguredClient(settings func() ClientSettings) (*HTTPClient, error) {
+	c := &HTTPClient{settings: settings}
+	if err := c.apply(settings()); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// refresh rebuilds the client if its settings have changed since the last
+// lookup. Invalid settings are logged and the previous ones kept.
+func (c *HTTPClient) refresh() {
+	if c.settings == nil {
+		return
+	}
+
+	settings := c.settings()
+	c.mu.RLock()
+	unchanged := settings == c.applied
+	c.mu.RUnlock()
+	if unchanged {
+		return
+	}
+
+	if err := c.apply(settings); err != nil {
+		log.Printf("Keeping previous holidays upstream settings: %v", err)
+		return
+	}
+	log.Printf("Applied holidays upstream settings for %s", c.snapshot().baseURL)
+}
+
+// apply builds a new transport from settings and swaps it in
+func (c *HTTPClient) apply(settings ClientSettings) error {
+	httpClient, err := buildHTTPClient(settings)
+	if err != nil {
+		return err
+	}
+
+	baseURL := settings.BaseURL
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	retry := settings.Retry
+	if retry.MaxAttempts == 0 {
+		retry = DefaultRetryPolicy
+	}
+
+	c.mu.Lock()
+	previous := c.httpClient
+	c.baseURL = baseURL
+	c.httpClient = httpClient
+	c.userAgent = settings.UserAgent
+	c.retry = retry
+	c.applied = settings
+	c.mu.Unlock()
+
+	if previous != nil {
+		previous.CloseIdleConnections()
+	}
+	return nil
+}
+
+// buildHTTPClient creates an http.Client with its own transport for settings
+func buildHTTPClient(settings ClientSettings) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if settings.ProxyURL != "" {
+		proxy, err := url.Parse(settings.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL: %w", err)
+		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: settings.InsecureSkipVerify,
+	}
+	if settings.CAFile != "" {
+		pem, err := os.ReadFile(settings.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA file: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", settings.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	transport.TLSClientConfig = tlsConfig
+
+	timeout := settings.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	return &http.Client{Timeout: timeout, Transport: transport}, nil
+}
@@ -0,0 +1,37 @@
+package holidays
+
+import "context"
+
+// Source describes where the holidays returned by a lookup came from.
+// Clients report it through the context so that the Client interface stays
+// a plain list of holidays.
+type Source struct {
+	// Provider names the data source that answered, e.g. "nager" or "disk"
+	Provider string
+	// Stale is set when a saved copy was served because the provider failed
+	Stale bool
+}
+
+type sourceKey struct{}
+
+// WithSource returns a context in which clients serving a lookup record
+// its Source, and the Source they will record into
+func WithSource(ctx context.Context) (context.Context, *Source) {
+	src := &Source{}
+	return context.WithValue(ctx, sourceKey{}, src), src
+}
+
+// setSource overwrites the Source being recorded in ctx, if any
+func setSource(ctx context.Context, src Source) {
+	if recorder, ok := ctx.Value(sourceKey{}).(*Source); ok {
+		*recorder = src
+	}
+}
+
+// setProvider records which provider served the lookup in ctx, unless a
+// more specific client further down the chain already did
+func setProvider(ctx context.Context, name string) {
+	if recorder, ok := ctx.Value(sourceKey{}).(*Source); ok && recorder.Provider == "" {
+		recorder.Provider = name
+	}
+}
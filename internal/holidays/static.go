@@ -0,0 +1,64 @@
+package holidays
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// StaticClient serves holidays from a fixed dataset held in memory
+type StaticClient struct {
+	holidays map[cacheKey][]Holiday
+}
+
+// NewStaticClient indexes holidays by country and by the year of their date
+func NewStaticClient(holidays []Holiday) (*StaticClient, error) {
+	c := &StaticClient{holidays: make(map[cacheKey][]Holiday)}
+	for _, holiday := range holidays {
+		year, err := holidayYear(holiday.Date)
+		if err != nil {
+			return nil, err
+		}
+		key := cacheKey{year: year, country: strings.ToUpper(holiday.CountryCode)}
+		c.holidays[key] = append(c.holidays[key], holiday)
+	}
+	return c, nil
+}
+
+// LoadStaticClient reads a JSON array of holidays from path
+func LoadStaticClient(path string) (*StaticClient, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read holiday dataset: %w", err)
+	}
+	var holidays []Holiday
+	if err := json.Unmarshal(data, &holidays); err != nil {
+		return nil, fmt.Errorf("failed to decode holiday dataset %s: %w", path, err)
+	}
+	return NewStaticClient(holidays)
+}
+
+// GetHolidays returns the dataset's holidays, or ErrNotFound if it has none
+// for the country and year
+func (c *StaticClient) GetHolidays(ctx context.Context, year int, countryCode string) ([]Holiday, error) {
+	holidays, ok := c.holidays[cacheKey{year: year, country: strings.ToUpper(countryCode)}]
+	if !ok {
+		return nil, fmt.Errorf("no %s holidays for %d in dataset: %w", countryCode, year, ErrNotFound)
+	}
+	return copyHolidays(holidays), nil
+}
+
+// holidayYear reads the year from a YYYY-MM-DD date
+func holidayYear(date string) (int, error) {
+	if len(date) < 4 {
+		return 0, fmt.Errorf("invalid holiday date %q", date)
+	}
+	year, err := strconv.Atoi(date[:4])
+	if err != nil {
+		return 0, fmt.Errorf("invalid holiday date %q", date)
+	}
+	return year, nil
+}
@@ -0,0 +1,61 @@
+package holidays
+
+import "context"
+
+// StreamingService is a Service that can hand over each country's result as
+// soon as it has been fetched, instead of waiting for the slowest country
+type StreamingService interface {
+	Service
+	StreamHolidaysForCountries(ctx context.Context, year int, countryCodes []string, emit func(CountryResult))
+}
+
+// StreamHolidays calls emit with each country's result for year as it
+// becomes ready. Services that cannot stream deliver every result once the
+// last one is fetched.
+func StreamHolidays(ctx context.Context, service Service, year int, countryCodes []string, emit func(CountryResult)) {
+	if streaming, ok := service.(StreamingService); ok {
+		streaming.StreamHolidaysForCountries(ctx, year, countryCodes, emit)
+		return
+	}
+	for _, result := range service.GetHolidaysForCountries(ctx, year, countryCodes) {
+		emit(result)
+	}
+}
+
+// StreamHolidaysForYears is the streaming form of GetHolidaysForYears. Like
+// it, the years are fetched one after another, so a request never runs more
+// lookups at once than the service allows for a single year. Each country's
+// merged result is emitted as soon as its last year is in. Repeated country
+// codes are fetched once. emit is called from the calling goroutine.
+func StreamHolidaysForYears(ctx context.Context, service Service, years []int, countryCodes []string, emit func(CountryResult)) {
+	var codes []string
+	seen := make(map[string]bool, len(countryCodes))
+	for _, code := range countryCodes {
+		if !seen[code] {
+			seen[code] = true
+			codes = append(codes, code)
+		}
+	}
+
+	if len(years) == 1 {
+		StreamHolidays(ctx, service, years[0], codes, emit)
+		return
+	}
+
+	byCountry := make(map[string]map[int]CountryResult, len(codes))
+	for _, year := range years {
+		StreamHolidays(ctx, service, year, codes, func(result CountryResult) {
+			code := result.CountryCode
+			if !seen[code] {
+				return
+			}
+			if byCountry[code] == nil {
+				byCountry[code] = make(map[int]CountryResult, len(years))
+			}
+			byCountry[code][year] = result
+			if len(byCountry[code]) == len(years) {
+				emit(mergeYears(code, years, byCountry[code]))
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package holidays
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+// gatedClient holds lookups for SLOW until release is closed
+type gatedClient struct {
+	release chan struct{}
+}
+
+func (g *gatedClient) GetHolidays(ctx context.Context, year int, countryCode string) ([]Holiday, error) {
+	if countryCode == "SLOW" {
+		select {
+		case <-g.release:
+		case <-time.After(2 * time.Second):
+			return nil, errors.New("not released")
+		}
+	}
+	return []Holiday{{Date: "2025-01-01", CountryCode: countryCode}}, nil
+}
+
+func TestStreamHolidaysForCountriesEmitsAsReady(t *testing.T) {
+	client := &gatedClient{release: make(chan struct{})}
+	service := NewService(client)
+
+	var order []string
+	StreamHolidays(context.Background(), service, 2025, []string{"SLOW", "FAST"}, func(result CountryResult) {
+		order = append(order, result.CountryCode)
+		if result.Error != "" {
+			t.Errorf("Unexpected error for %s: %s", result.CountryCode, result.Error)
+		}
+		if result.CountryCode == "FAST" {
+			close(client.release)
+		}
+	})
+
+	if len(order) != 2 || order[0] != "FAST" {
+		t.Errorf("Expected FAST to be emitted before SLOW finished, got %v", order)
+	}
+}
+
+// batchService only implements the plain Service interface
+type batchService struct {
+	Service
+}
+
+func TestStreamHolidaysForYears(t *testing.T) {
+	for name, service := range map[string]Service{
+		"streaming": NewService(NewRuleClient(DefaultRules)),
+		"batch":     batchService{NewService(NewRuleClient(DefaultRules))},
+	} {
+		t.Run(name, func(t *testing.T) {
+			counts := make(map[string]int)
+			StreamHolidaysForYears(context.Background(), service, []int{2025, 2026}, []string{"DE", "US", "DE"}, func(result CountryResult) {
+				counts[result.CountryCode]++
+				if result.Error != "" {
+					t.Errorf("Unexpected error for %s: %s", result.CountryCode, result.Error)
+				}
+				first, last := result.Holidays[0].Date, result.Holidays[len(result.Holidays)-1].Date
+				if first[:4] != "2025" || last[:4] != "2026" {
+					t.Errorf("Expected %s to span both years, got %s to %s", result.CountryCode, first, last)
+				}
+			})
+
+			if len(counts) != 2 || counts["DE"] != 1 || counts["US"] != 1 {
+				t.Errorf("Expected one result per distinct country, got %v", counts)
+			}
+		})
+	}
+}
+
+func TestStreamHolidaysForYearsReportsErrors(t *testing.T) {
+	service := NewService(&mockClient{err: errors.New("upstream down")})
+
+	var results []CountryResult
+	StreamHolidaysForYears(context.Background(), service, []int{2025, 2026}, []string{"DE"}, func(result CountryResult) {
+		results = append(results, result)
+	})
+
+	if len(results) != 1 {
+		t.Fatalf("Expected 1 result, got %d", len(results))
+	}
+	if want := "2025: upstream down; 2026: upstream down"; results[0].Error != want {
+		t.Errorf("Expected error %q, got %q", want, results[0].Error)
+	}
+}
+
+// peakClient records the most lookups that were in flight at once
+type peakClient struct {
+	mu       sync.Mutex
+	inFlight int
+	peak     int
+}
+
+func (p *peakClient) GetHolidays(ctx context.Context, year int, countryCode string) ([]Holiday, error) {
+	p.mu.Lock()
+	p.inFlight++
+	if p.inFlight > p.peak {
+		p.peak = p.inFlight
+	}
+	p.mu.Unlock()
+
+	time.Sleep(10 * time.Millisecond)
+
+	p.mu.Lock()
+	p.inFlight--
+	p.mu.Unlock()
+	return []Holiday{{Date: fmt.Sprintf("%d-01-01", year), CountryCode: countryCode}}, nil
+}
+
+func TestStreamHolidaysForYearsKeepsConcurrencyLimit(t *testing.T) {
+	client := &peakClient{}
+	service := NewService(client, WithConcurrency(2))
+
+	StreamHolidaysForYears(context.Background(), service, []int{2025, 2026, 2027}, []string{"DE", "FR", "GB"}, func(CountryResult) {})
+
+	if client.peak > 2 {
+		t.Errorf("Expected at most 2 lookups at once, got %d", client.peak)
+	}
+}
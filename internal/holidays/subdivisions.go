@@ -0,0 +1,28 @@
+package holidays
+
+// knownSubdivisions lists the ISO 3166-2 subdivisions of countries whose
+// regional holidays are commonly asked for. A subdivision of one of these
+// countries is accepted even in a year none of its holidays fall in, such
+// as DE-BE before Women's Day was introduced in 2019. Other countries are
+// checked against their holiday data instead.
+var knownSubdivisions = map[string][]string{
+	"CA": {
+		"CA-AB", "CA-BC", "CA-MB", "CA-NB", "CA-NL", "CA-NS", "CA-NT",
+		"CA-NU", "CA-ON", "CA-PE", "CA-QC", "CA-SK", "CA-YT",
+	},
+	"DE": {
+		"DE-BB", "DE-BE", "DE-BW", "DE-BY", "DE-HB", "DE-HE", "DE-HH", "DE-MV",
+		"DE-NI", "DE-NW", "DE-RP", "DE-SH", "DE-SL", "DE-SN", "DE-ST", "DE-TH",
+	},
+	"GB": {"GB-ENG", "GB-NIR", "GB-SCT", "GB-WLS"},
+	"US": {
+		"US-AK", "US-AL", "US-AR", "US-AZ", "US-CA", "US-CO", "US-CT", "US-DC",
+		"US-DE", "US-FL", "US-GA", "US-HI", "US-IA", "US-ID", "US-IL", "US-IN",
+		"US-KS", "US-KY", "US-LA", "US-MA", "US-MD", "US-ME", "US-MI", "US-MN",
+		"US-MO", "US-MS", "US-MT", "US-NC", "US-ND", "US-NE", "US-NH", "US-NJ",
+		"US-NM", "US-NV", "US-NY", "US-OH", "US-OK", "US-OR", "US-PA", "US-RI",
+		"US-SC", "US-SD", "US-TN", "US-TX", "US-UT", "US-VA", "US-VT", "US-WA",
+		"US-WI", "US-WV", "US-WY",
+		"US-AS", "US-GU", "US-MP", "US-PR", "US-UM", "US-VI",
+	},
+}
@@ -0,0 +1,75 @@
+package holidays
+
+import (
+	"strings"
+	"time"
+	// Bundle time zone data so countries resolve on hosts without it
+	_ "time/tzdata"
+)
+
+// countryTimezones maps countries to the time zone most of their
+// population lives in, used to decide what "today" is in a country
+var countryTimezones = map[string]string{
+	"AE": "Asia/Dubai",
+	"AR": "America/Argentina/Buenos_Aires",
+	"AT": "Europe/Vienna",
+	"AU": "Australia/Sydney",
+	"BE": "Europe/Brussels",
+	"BR": "America/Sao_Paulo",
+	"CA": "America/Toronto",
+	"CH": "Europe/Zurich",
+	"CL": "America/Santiago",
+	"CN": "Asia/Shanghai",
+	"CZ": "Europe/Prague",
+	"DE": "Europe/Berlin",
+	"DK": "Europe/Copenhagen",
+	"ES": "Europe/Madrid",
+	"FI": "Europe/Helsinki",
+	"FR": "Europe/Paris",
+	"GB": "Europe/London",
+	"GR": "Europe/Athens",
+	"HK": "Asia/Hong_Kong",
+	"HU": "Europe/Budapest",
+	"ID": "Asia/Jakarta",
+	"IE": "Europe/Dublin",
+	"IN": "Asia/Kolkata",
+	"IT": "Europe/Rome",
+	"JP": "Asia/Tokyo",
+	"KR": "Asia/Seoul",
+	"MX": "America/Mexico_City",
+	"MY": "Asia/Kuala_Lumpur",
+	"NL": "Europe/Amsterdam",
+	"NO": "Europe/Oslo",
+	"NZ": "Pacific/Auckland",
+	"PH": "Asia/Manila",
+	"PL": "Europe/Warsaw",
+	"PT": "Europe/Lisbon",
+	"SA": "Asia/Riyadh",
+	"SE": "Europe/Stockholm",
+	"SG": "Asia/Singapore",
+	"TH": "Asia/Bangkok",
+	"TR": "Europe/Istanbul",
+	"TW": "Asia/Taipei",
+	"US": "America/New_York",
+	"VN": "Asia/Ho_Chi_Minh",
+	"ZA": "Africa/Johannesburg",
+}
+
+// CountryLocation returns the time zone of country, or UTC for countries
+// that are not known
+func CountryLocation(country string) *time.Location {
+	name, ok := countryTimezones[strings.ToUpper(country)]
+	if !ok {
+		return time.UTC
+	}
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return time.UTC
+	}
+	return location
+}
+
+// Today returns the current date in location, as a date in UTC
+func Today(now time.Time, location *time.Location) time.Time {
+	return truncateDay(now.In(location))
+}
@@ -0,0 +1,111 @@
+// Package ical writes all-day events as iCalendar (RFC 5545) data
+package ical
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ContentType is the media type of iCalendar data
+const ContentType = "text/calendar"
+
+// productID identifies the application that created the calendar
+const productID = "-//kln-test//Public Holidays//EN"
+
+// maxLineOctets is the longest content line allowed before folding
+const maxLineOctets = 75
+
+// Event is an all-day event
+type Event struct {
+	// UID must stay the same for the same event across downloads so that
+	// calendar clients update it instead of adding a duplicate
+	UID         string
+	Date        time.Time
+	Summary     string
+	Description string
+	Categories  []string
+}
+
+// Calendar is a named collection of events
+type Calendar struct {
+	Name   string
+	Events []Event
+}
+
+// Write encodes calendars as an iCalendar stream, one VCALENDAR per
+// calendar. stamp is used as the DTSTAMP of every event.
+func Write(w io.Writer, stamp time.Time, calendars ...Calendar) error {
+	bw := bufio.NewWriter(w)
+	line := func(name, value string) {
+		writeLine(bw, name+":"+value)
+	}
+
+	for _, calendar := range calendars {
+		line("BEGIN", "VCALENDAR")
+		line("VERSION", "2.0")
+		line("PRODID", productID)
+		line("CALSCALE", "GREGORIAN")
+		line("METHOD", "PUBLISH")
+		if calendar.Name != "" {
+			line("X-WR-CALNAME", escape(calendar.Name))
+		}
+
+		for _, event := range calendar.Events {
+			line("BEGIN", "VEVENT")
+			line("UID", escape(event.UID))
+			line("DTSTAMP", stamp.UTC().Format("20060102T150405Z"))
+			line("DTSTART;VALUE=DATE", event.Date.Format("20060102"))
+			line("DTEND;VALUE=DATE", event.Date.AddDate(0, 0, 1).Format("20060102"))
+			line("SUMMARY", escape(event.Summary))
+			if event.Description != "" {
+				line("DESCRIPTION", escape(event.Description))
+			}
+			if len(event.Categories) > 0 {
+				categories := make([]string, len(event.Categories))
+				for i, category := range event.Categories {
+					categories[i] = escape(category)
+				}
+				line("CATEGORIES", strings.Join(categories, ","))
+			}
+			// Holidays should not show the day as busy
+			line("TRANSP", "TRANSPARENT")
+			line("END", "VEVENT")
+		}
+
+		line("END", "VCALENDAR")
+	}
+	return bw.Flush()
+}
+
+// escape escapes a TEXT value
+func escape(value string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(value)
+}
+
+// writeLine writes a content line, folding it into continuation lines of at
+// most 75 octets without splitting a UTF-8 character
+func writeLine(w *bufio.Writer, line string) {
+	limit := maxLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		w.WriteString(line[:cut])
+		w.WriteString("\r\n ")
+		line = line[cut:]
+		// Continuation lines start with a space, which counts towards the limit
+		limit = maxLineOctets - 1
+	}
+	w.WriteString(line)
+	w.WriteString("\r\n")
+}
@@ -0,0 +1,61 @@
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWrite(t *testing.T) {
+	var buf bytes.Buffer
+	stamp := time.Date(2025, time.March, 1, 12, 0, 0, 0, time.UTC)
+	err := Write(&buf, stamp, Calendar{
+		Name: "Holidays; DE",
+		Events: []Event{{
+			UID:         "20251225-DE@holidays",
+			Date:        time.Date(2025, time.December, 25, 0, 0, 0, 0, time.UTC),
+			Summary:     "Christmas Day, Erster Weihnachtstag",
+			Description: strings.Repeat("Frohe Weihnachten ", 6),
+			Categories:  []string{"Public"},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	output := buf.String()
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\nVERSION:2.0\r\n",
+		"X-WR-CALNAME:Holidays\\; DE\r\n",
+		"UID:20251225-DE@holidays\r\n",
+		"DTSTAMP:20250301T120000Z\r\n",
+		"DTSTART;VALUE=DATE:20251225\r\nDTEND;VALUE=DATE:20251226\r\n",
+		"SUMMARY:Christmas Day\\, Erster Weihnachtstag\r\n",
+		"CATEGORIES:Public\r\n",
+		"END:VEVENT\r\nEND:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+
+	for _, line := range strings.Split(strings.TrimSuffix(output, "\r\n"), "\r\n") {
+		if len(line) > maxLineOctets {
+			t.Errorf("Expected lines of at most %d octets, got %d: %q", maxLineOctets, len(line), line)
+		}
+	}
+	if !strings.Contains(output, "\r\n ") {
+		t.Error("Expected the long description to be folded")
+	}
+}
+
+func TestWriteFoldsWithoutSplittingCharacters(t *testing.T) {
+	var buf bytes.Buffer
+	Write(&buf, time.Now(), Calendar{Name: strings.Repeat("Ωμέγα ", 30)})
+
+	unfolded := strings.ReplaceAll(buf.String(), "\r\n ", "")
+	if !strings.Contains(unfolded, "X-WR-CALNAME:"+strings.Repeat("Ωμέγα ", 30)+"\r\n") {
+		t.Errorf("Expected the folded name to unfold to the original, got:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,202 @@
+// Package render writes handler responses in the format a client asks for,
+// negotiated from the Accept header or a format query parameter
+package render
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Media types that responses can be rendered as
+const (
+	JSON   = "application/json"
+	CSV    = "text/csv"
+	NDJSON = "application/x-ndjson"
+)
+
+// formats maps the names accepted by the format query parameter to media
+// types. Types without an encoder here are added with RegisterFormat by the
+// package that writes them.
+var formats = map[string]string{
+	"json":   JSON,
+	"csv":    CSV,
+	"ndjson": NDJSON,
+}
+
+// RegisterFormat lets the format query parameter name mediaType, for media
+// types that a handler offers and writes itself. It is meant to be called
+// from an init function, before any request is negotiated.
+func RegisterFormat(name, mediaType string) {
+	formats[strings.ToLower(name)] = mediaType
+}
+
+// Table is implemented by responses that can be flattened into CSV rows
+type Table interface {
+	CSVHeader() []string
+	CSVRows() [][]string
+}
+
+// RecordSet is implemented by responses that can be written as NDJSON,
+// one record per line
+type RecordSet interface {
+	Records() []interface{}
+}
+
+// Offers returns the media types v can be rendered as, JSON first
+func Offers(v interface{}) []string {
+	offers := []string{JSON}
+	if _, ok := v.(Table); ok {
+		offers = append(offers, CSV)
+	}
+	if _, ok := v.(RecordSet); ok {
+		offers = append(offers, NDJSON)
+	}
+	return offers
+}
+
+// Negotiate returns the offered media type the client prefers, or "" if it
+// accepts none of them. A format query parameter such as format=csv takes
+// precedence over the Accept header; without either, the first offer wins.
+func Negotiate(r *http.Request, offers ...string) string {
+	if format := r.URL.Query().Get("format"); format != "" {
+		mediaType := formats[strings.ToLower(format)]
+		for _, offer := range offers {
+			if offer == mediaType {
+				return offer
+			}
+		}
+		return ""
+	}
+
+	accept := r.Header.Get("Accept")
+	if accept == "" {
+		return offers[0]
+	}
+	ranges := parseAccept(accept)
+
+	// Prefer the highest q-value, then the offer named most specifically,
+	// so that "text/csv, */*" picks CSV over the default
+	best, bestQ, bestSpecificity := "", 0.0, -1
+	for _, offer := range offers {
+		q, specificity := quality(ranges, offer)
+		if q > bestQ || (q == bestQ && q > 0 && specificity > bestSpecificity) {
+			best, bestQ, bestSpecificity = offer, q, specificity
+		}
+	}
+	return best
+}
+
+// Write renders v in the format negotiated with the client, answering 406
+// Not Acceptable if v cannot be rendered in any format the client accepts.
+// Values that can only be rendered as JSON are sent as JSON whatever the
+// Accept header says, unless another format was asked for by name.
+func Write(w http.ResponseWriter, r *http.Request, status int, v interface{}) {
+	offers := Offers(v)
+	mediaType := Negotiate(r, offers...)
+	if mediaType == "" && len(offers) == 1 && r.URL.Query().Get("format") == "" {
+		mediaType = JSON
+	}
+	if mediaType == "" {
+		http.Error(w, "Not acceptable, supported types are "+strings.Join(Offers(v), ", "), http.StatusNotAcceptable)
+		return
+	}
+	Encode(w, mediaType, status, v)
+}
+
+// Encode writes v as mediaType, which must be one of Offers(v)
+func Encode(w http.ResponseWriter, mediaType string, status int, v interface{}) {
+	switch mediaType {
+	case CSV:
+		table := v.(Table)
+		w.Header().Set("Content-Type", CSV+"; charset=utf-8")
+		w.WriteHeader(status)
+		cw := csv.NewWriter(w)
+		cw.Write(table.CSVHeader())
+		for _, row := range table.CSVRows() {
+			cw.Write(neutralize(row))
+		}
+		cw.Flush()
+	case NDJSON:
+		w.Header().Set("Content-Type", NDJSON)
+		w.WriteHeader(status)
+		encoder := json.NewEncoder(w)
+		for _, record := range v.(RecordSet).Records() {
+			encoder.Encode(record)
+		}
+	default:
+		w.Header().Set("Content-Type", JSON)
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(v)
+	}
+}
+
+// neutralize prefixes cells that a spreadsheet would read as a formula with
+// a quote, so that a holiday name cannot run code when the file is opened
+func neutralize(row []string) []string {
+	out := make([]string, len(row))
+	for i, cell := range row {
+		if cell != "" && strings.ContainsRune("=+-@\t\r", rune(cell[0])) {
+			cell = "'" + cell
+		}
+		out[i] = cell
+	}
+	return out
+}
+
+// mediaRange is one entry of an Accept header
+type mediaRange struct {
+	mediaType string
+	q         float64
+}
+
+func parseAccept(accept string) []mediaRange {
+	var ranges []mediaRange
+	for _, part := range strings.Split(accept, ",") {
+		params := strings.Split(part, ";")
+		entry := mediaRange{mediaType: strings.ToLower(strings.TrimSpace(params[0])), q: 1}
+		for _, param := range params[1:] {
+			name, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+			if strings.EqualFold(name, "q") {
+				if q, err := strconv.ParseFloat(value, 64); err == nil {
+					entry.q = q
+				}
+			}
+		}
+		if entry.mediaType != "" {
+			ranges = append(ranges, entry)
+		}
+	}
+
+	// The most specific range decides, so try exact types before wildcards
+	sort.SliceStable(ranges, func(i, j int) bool {
+		return specificity(ranges[i].mediaType) > specificity(ranges[j].mediaType)
+	})
+	return ranges
+}
+
+func specificity(mediaType string) int {
+	switch {
+	case mediaType == "*/*":
+		return 0
+	case strings.HasSuffix(mediaType, "/*"):
+		return 1
+	default:
+		return 2
+	}
+}
+
+// quality returns the q-value the client gives offer, zero if unacceptable,
+// and the specificity of the range it was taken from
+func quality(ranges []mediaRange, offer string) (float64, int) {
+	for _, r := range ranges {
+		if r.mediaType == offer || r.mediaType == "*/*" ||
+			(strings.HasSuffix(r.mediaType, "/*") && strings.HasPrefix(offer, strings.TrimSuffix(r.mediaType, "*"))) {
+			return r.q, specificity(r.mediaType)
+		}
+	}
+	return 0, -1
+}
@@ -0,0 +1,172 @@
+package render
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type testTable struct{}
+
+func (testTable) CSVHeader() []string { return []string{"name", "note"} }
+func (testTable) CSVRows() [][]string {
+	return [][]string{{"a", "x, y"}, {"b", ""}, {"c", "=1+1"}, {"d", "-2"}, {"e", "@SUM(A1)"}}
+}
+func (testTable) Records() []interface{} {
+	return []interface{}{map[string]string{"name": "a"}, map[string]string{"name": "b"}}
+}
+
+func TestNegotiate(t *testing.T) {
+	offers := []string{JSON, CSV, NDJSON}
+	tests := []struct {
+		name   string
+		url    string
+		accept string
+		want   string
+	}{
+		{name: "no accept header", url: "/", want: JSON},
+		{name: "any type", url: "/", accept: "*/*", want: JSON},
+		{name: "exact type", url: "/", accept: "text/csv", want: CSV},
+		{name: "type with charset", url: "/", accept: "text/csv; charset=utf-8", want: CSV},
+		{name: "specific type beats wildcard", url: "/", accept: "application/x-ndjson, */*", want: NDJSON},
+		{name: "q-values", url: "/", accept: "text/csv;q=0.5, application/json;q=0.9", want: JSON},
+		{name: "subtype wildcard", url: "/", accept: "text/*", want: CSV},
+		{name: "excluded type", url: "/", accept: "application/json;q=0, */*;q=0.1", want: CSV},
+		{name: "browser default", url: "/", accept: "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8", want: JSON},
+		{name: "nothing acceptable", url: "/", accept: "text/html", want: ""},
+		{name: "format parameter", url: "/?format=ndjson", accept: "application/json", want: NDJSON},
+		{name: "unknown format", url: "/?format=xml", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			if got := Negotiate(req, offers...); got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRegisterFormat(t *testing.T) {
+	RegisterFormat("TSV", "text/tab-separated-values")
+	defer delete(formats, "tsv")
+
+	tests := []struct {
+		name   string
+		offers []string
+		want   string
+	}{
+		{name: "offered", offers: []string{JSON, "text/tab-separated-values"}, want: "text/tab-separated-values"},
+		{name: "not offered", offers: []string{JSON, CSV}, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/?format=tsv", nil)
+			if got := Negotiate(req, tt.offers...); got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestWrite(t *testing.T) {
+	tests := []struct {
+		name            string
+		url             string
+		accept          string
+		value           interface{}
+		wantStatus      int
+		wantContentType string
+		wantBody        string
+	}{
+		{
+			name:            "json",
+			value:           testTable{},
+			wantStatus:      http.StatusCreated,
+			wantContentType: JSON,
+			wantBody:        "{}\n",
+		},
+		{
+			name:            "csv",
+			accept:          CSV,
+			value:           testTable{},
+			wantStatus:      http.StatusCreated,
+			wantContentType: CSV + "; charset=utf-8",
+			wantBody:        "name,note\na,\"x, y\"\nb,\nc,'=1+1\nd,'-2\ne,'@SUM(A1)\n",
+		},
+		{
+			name:            "ndjson",
+			accept:          NDJSON,
+			value:           testTable{},
+			wantStatus:      http.StatusCreated,
+			wantContentType: NDJSON,
+			wantBody:        "{\"name\":\"a\"}\n{\"name\":\"b\"}\n",
+		},
+		{
+			name:            "csv for a value without rows",
+			accept:          CSV,
+			value:           map[string]string{"name": "a"},
+			wantStatus:      http.StatusCreated,
+			wantContentType: JSON,
+			wantBody:        "{\"name\":\"a\"}\n",
+		},
+		{
+			name:            "unrelated type for a value without rows",
+			accept:          "text/plain",
+			value:           map[string]string{"name": "a"},
+			wantStatus:      http.StatusCreated,
+			wantContentType: JSON,
+			wantBody:        "{\"name\":\"a\"}\n",
+		},
+		{
+			name:       "csv by name for a value without rows",
+			url:        "/?format=csv",
+			value:      map[string]string{"name": "a"},
+			wantStatus: http.StatusNotAcceptable,
+		},
+		{
+			name:       "unacceptable type for a table",
+			accept:     "text/plain",
+			value:      testTable{},
+			wantStatus: http.StatusNotAcceptable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			url := tt.url
+			if url == "" {
+				url = "/"
+			}
+			req := httptest.NewRequest(http.MethodGet, url, nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			rec := httptest.NewRecorder()
+
+			Write(rec, req, http.StatusCreated, tt.value)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, rec.Code)
+			}
+			if tt.wantStatus == http.StatusNotAcceptable {
+				if !strings.Contains(rec.Body.String(), JSON) {
+					t.Errorf("Expected the supported types to be listed, got %q", rec.Body.String())
+				}
+				return
+			}
+			if got := rec.Header().Get("Content-Type"); got != tt.wantContentType {
+				t.Errorf("Expected content type %q, got %q", tt.wantContentType, got)
+			}
+			if rec.Body.String() != tt.wantBody {
+				t.Errorf("Expected body %q, got %q", tt.wantBody, rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,227 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// BatchFunc processes payloads that share a batch key in a single call. It
+// returns nil when every payload succeeded, or one error per payload
+// otherwise, so that only the failed items are retried.
+type BatchFunc[T any] func(ctx context.Context, payloads []T) []error
+
+// SetBatchProcessor enables batching for jobs that carry a BatchKey.
+// Batches are flushed once worker.batch.maxSize jobs have accumulated or
+// worker.batch.maxWait milliseconds after the first one arrived.
+func (p *Pool[T]) SetBatchProcessor(fn BatchFunc[T]) {
+	p.batcher.mu.Lock()
+	defer p.batcher.mu.Unlock()
+	p.batcher.process = fn
+}
+
+// batcher accumulates jobs per batch key until they are flushed
+type batcher[T any] struct {
+	mu      sync.Mutex
+	process BatchFunc[T]
+	pending map[string]*pendingBatch[T]
+}
+
+// processor returns the configured batch processor, if any
+func (b *batcher[T]) processor() BatchFunc[T] {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.process
+}
+
+type pendingBatch[T any] struct {
+	jobs  []Job[T]
+	timer *time.Timer
+}
+
+func newBatcher[T any]() *batcher[T] {
+	return &batcher[T]{pending: make(map[string]*pendingBatch[T])}
+}
+
+// takeAll removes and returns every pending batch as a queueable job
+func (b *batcher[T]) takeAll() []Job[T] {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	jobs := make([]Job[T], 0, len(b.pending))
+	for key, pending := range b.pending {
+		pending.timer.Stop()
+		jobs = append(jobs, batchJob(key, pending.jobs))
+		delete(b.pending, key)
+	}
+	return jobs
+}
+
+// batchJob wraps member jobs into a single queue entry
+func batchJob[T any](key string, jobs []Job[T]) Job[T] {
+	return Job[T]{
+		ID:       fmt.Sprintf("batch %s (%d jobs)", key, len(jobs)),
+		BatchKey: key,
+		batch:    jobs,
+	}
+}
+
+// batching reports whether job should be accumulated rather than queued directly
+func (p *Pool[T]) batching(job Job[T]) bool {
+	return job.BatchKey != "" && p.cfg.GetWorkerConfig().Batch.MaxSize > 1 && p.batcher.processor() != nil
+}
+
+// addToBatch appends job to the pending batch for its key and queues the
+// batch once it is full. It reports false, without adding job, if a full
+// batch does not fit in the queue. The caller must hold p.mu for reading.
+func (p *Pool[T]) addToBatch(job Job[T]) bool {
+	batchConfig := p.cfg.GetWorkerConfig().Batch
+	b := p.batcher
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	pending, ok := b.pending[job.BatchKey]
+	if !ok {
+		key := job.BatchKey
+		pending = &pendingBatch[T]{}
+		pending.timer = time.AfterFunc(time.Duration(batchConfig.MaxWait)*time.Millisecond, func() {
+			p.flushBatch(key, pending)
+		})
+		b.pending[key] = pending
+	}
+
+	if len(pending.jobs)+1 < batchConfig.MaxSize {
+		pending.jobs = append(pending.jobs, job)
+		return true
+	}
+
+	// This job fills the batch, so hand it to the workers now
+	select {
+	case p.jobs <- batchJob(job.BatchKey, append(pending.jobs, job)):
+		pending.timer.Stop()
+		delete(b.pending, job.BatchKey)
+		return true
+	default:
+		return false
+	}
+}
+
+// flushBatch queues a batch whose wait time has elapsed. If the queue is
+// full the batch stays pending and is tried again after another wait.
+func (p *Pool[T]) flushBatch(key string, pending *pendingBatch[T]) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	// Drain takes over any batches still pending once the pool is closed
+	if p.closed {
+		return
+	}
+
+	b := p.batcher
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.pending[key] != pending {
+		return
+	}
+
+	select {
+	case p.jobs <- batchJob(key, pending.jobs):
+		delete(b.pending, key)
+	default:
+		pending.timer.Reset(time.Duration(p.cfg.GetWorkerConfig().Batch.MaxWait) * time.Millisecond)
+	}
+}
+
+// batchState tracks which members of a batch have been processed. Attempts
+// that time out keep running in the background, so access is synchronized.
+type batchState[T any] struct {
+	mu   sync.Mutex
+	jobs []Job[T]
+	done []bool
+}
+
+// remaining returns the indexes and payloads of unprocessed members
+func (s *batchState[T]) remaining() ([]int, []T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var (
+		indexes  []int
+		payloads []T
+	)
+	for i, job := range s.jobs {
+		if !s.done[i] {
+			indexes = append(indexes, i)
+			payloads = append(payloads, job.Payload)
+		}
+	}
+	return indexes, payloads
+}
+
+// record marks the members that succeeded and returns an error if any failed
+func (s *batchState[T]) record(indexes []int, errs []error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if errs == nil {
+		for _, i := range indexes {
+			s.done[i] = true
+		}
+		return nil
+	}
+	if len(errs) != len(indexes) {
+		return fmt.Errorf("batch processor returned %d results for %d items", len(errs), len(indexes))
+	}
+
+	var failed int
+	for n, i := range indexes {
+		if errs[n] == nil {
+			s.done[i] = true
+		} else {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d batch items failed", failed, len(indexes))
+	}
+	return nil
+}
+
+func (s *batchState[T]) isDone(i int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.done[i]
+}
+
+// processBatchWithRetry runs a batch through the retry loop, retrying only
+// the members that have not yet succeeded
+func (p *Pool[T]) processBatchWithRetry(workerID int, job Job[T]) {
+	process := p.batcher.processor()
+	state := &batchState[T]{jobs: job.batch, done: make([]bool, len(job.batch))}
+	for _, member := range job.batch {
+		p.tracker.running(member.ID)
+	}
+
+	result := p.retry(workerID, job.ID, func(ctx context.Context) error {
+		indexes, payloads := state.remaining()
+		return state.record(indexes, process(ctx, payloads))
+	})
+
+	for i, member := range job.batch {
+		switch {
+		case state.isDone(i):
+			p.completed.Add(1)
+			p.tracker.complete(member.ID)
+			member.notify(outcomeCompleted)
+		case result == outcomeAborted:
+			p.abandon(member, "interrupted by shutdown")
+		default:
+			p.failed.Add(1)
+			p.tracker.forget(member.ID)
+			member.notify(outcomeFailed)
+		}
+	}
+}
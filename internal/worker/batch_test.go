@@ -0,0 +1,86 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"kln-test/internal/config"
+)
+
+func TestBatchRetriesOnlyFailedItems(t *testing.T) {
+	cfg := testConfig(1, 5, "")
+	cfg.Worker.Retry = config.WorkerRetryConfig{MaxAttempts: 2, InitialTimeout: 1, MaxTimeout: 1}
+	cfg.Worker.Batch.MaxSize = 3
+	cfg.Worker.Batch.MaxWait = 60000
+	pool := NewPool[int](cfg)
+
+	var (
+		mu    sync.Mutex
+		calls [][]int
+	)
+	pool.SetBatchProcessor(func(ctx context.Context, payloads []int) []error {
+		mu.Lock()
+		defer mu.Unlock()
+		calls = append(calls, append([]int(nil), payloads...))
+
+		if len(calls) > 1 {
+			return nil
+		}
+		errs := make([]error, len(payloads))
+		for i, n := range payloads {
+			if n == 2 {
+				errs[i] = errors.New("delivery failed")
+			}
+		}
+		return errs
+	})
+
+	for i := 1; i <= 3; i++ {
+		if err := pool.Submit(Job[int]{ID: "job", Payload: i, BatchKey: "http://example.com"}); err != nil {
+			t.Fatalf("Unexpected submit error: %v", err)
+		}
+	}
+
+	summary := pool.Drain(context.Background())
+	if summary.Completed != 3 {
+		t.Errorf("Expected 3 completed jobs, got %+v", summary)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(calls) != 2 {
+		t.Fatalf("Expected 2 batch calls, got %v", calls)
+	}
+	if len(calls[0]) != 3 {
+		t.Errorf("Expected first call with 3 items, got %v", calls[0])
+	}
+	if len(calls[1]) != 1 || calls[1][0] != 2 {
+		t.Errorf("Expected retry with only the failed item, got %v", calls[1])
+	}
+}
+
+func TestDrainFlushesPartialBatches(t *testing.T) {
+	cfg := testConfig(1, 5, "")
+	cfg.Worker.Batch.MaxSize = 10
+	cfg.Worker.Batch.MaxWait = 60000
+	pool := NewPool[int](cfg)
+
+	var processed int
+	pool.SetBatchProcessor(func(ctx context.Context, payloads []int) []error {
+		processed += len(payloads)
+		return nil
+	})
+
+	for i := 0; i < 4; i++ {
+		if err := pool.Submit(Job[int]{ID: "job", Payload: i, BatchKey: "key"}); err != nil {
+			t.Fatalf("Unexpected submit error: %v", err)
+		}
+	}
+
+	summary := pool.Drain(context.Background())
+	if summary.Completed != 4 || processed != 4 {
+		t.Errorf("Expected the pending batch to be processed on drain, got %+v (%d processed)", summary, processed)
+	}
+}
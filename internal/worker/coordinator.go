@@ -0,0 +1,142 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+)
+
+// Coordinator feeds jobs claimed from a shared FileStore into a local Pool
+// and keeps their leases alive while they are queued or running. Jobs that
+// cannot be finished locally, for example because the pool is shut down,
+// are released so that another process can claim them.
+type Coordinator[T any] struct {
+	store     *FileStore[T]
+	pool      *Pool[T]
+	build     func(id string, payload T) Job[T]
+	poll      time.Duration
+	heartbeat time.Duration
+}
+
+// NewCoordinator creates a coordinator that polls store every poll interval.
+// build turns a stored payload into the job submitted to pool.
+func NewCoordinator[T any](store *FileStore[T], pool *Pool[T], build func(id string, payload T) Job[T], poll time.Duration) (*Coordinator[T], error) {
+	if poll <= 0 {
+		return nil, fmt.Errorf("poll interval must be positive, got %s", poll)
+	}
+	heartbeat := store.ttl / 3
+	if heartbeat <= 0 {
+		heartbeat = store.ttl
+	}
+	return &Coordinator[T]{
+		store:     store,
+		pool:      pool,
+		build:     build,
+		poll:      poll,
+		heartbeat: heartbeat,
+	}, nil
+}
+
+// Run claims jobs until ctx is cancelled
+func (c *Coordinator[T]) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.poll)
+	defer ticker.Stop()
+
+	for {
+		c.claimAvailable()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// claimAvailable claims and submits jobs until the store is empty or the
+// pool stops accepting work
+func (c *Coordinator[T]) claimAvailable() {
+	for {
+		lease, err := c.store.Claim()
+		if err != nil {
+			log.Printf("Failed to claim job from store: %v", err)
+			return
+		}
+		if lease == nil || !c.submit(lease) {
+			return
+		}
+	}
+}
+
+// submit hands a claimed job to the pool, reporting whether it was accepted
+func (c *Coordinator[T]) submit(lease *Lease[T]) bool {
+	stop := make(chan struct{})
+
+	job := c.build(lease.ID, lease.Payload)
+	job.stored = true
+	job.done = func(result outcome) {
+		close(stop)
+		c.finish(lease, result)
+	}
+
+	err := c.pool.Submit(job)
+	if err == nil {
+		go c.keepAlive(lease, stop)
+		return true
+	}
+
+	var duplicate *DuplicateJobError
+	if errors.As(err, &duplicate) {
+		// This process already handled the same ID within the dedup window
+		c.complete(lease)
+		return true
+	}
+
+	// The pool is full or closed; leave the job for later or for another process
+	if err := lease.Release(); err != nil {
+		log.Printf("Failed to release lease for job %s: %v", lease.ID, err)
+	}
+	return false
+}
+
+// finish removes processed jobs from the store and releases interrupted
+// ones, which the pool does not dead-letter
+func (c *Coordinator[T]) finish(lease *Lease[T], result outcome) {
+	switch result {
+	case outcomeCompleted:
+		c.complete(lease)
+	case outcomeFailed:
+		log.Printf("Removing failed job %s from store", lease.ID)
+		c.complete(lease)
+	case outcomeAborted:
+		if err := lease.Release(); err != nil {
+			log.Printf("Failed to release lease for job %s: %v", lease.ID, err)
+		}
+	}
+}
+
+func (c *Coordinator[T]) complete(lease *Lease[T]) {
+	if err := lease.Complete(); err != nil {
+		log.Printf("Failed to complete job %s in store: %v", lease.ID, err)
+	}
+}
+
+// keepAlive renews the lease until stop is closed or the lease is lost
+func (c *Coordinator[T]) keepAlive(lease *Lease[T], stop <-chan struct{}) {
+	ticker := time.NewTicker(c.heartbeat)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			if err := lease.Heartbeat(); err != nil {
+				log.Printf("Stopped heartbeat for job %s: %v", lease.ID, err)
+				return
+			}
+		}
+	}
+}
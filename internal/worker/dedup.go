@@ -0,0 +1,113 @@
+package worker
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// JobStatus describes where a job is in its lifecycle
+type JobStatus string
+
+const (
+	StatusQueued    JobStatus = "queued"
+	StatusRunning   JobStatus = "running"
+	StatusCompleted JobStatus = "completed"
+)
+
+// DuplicateJobError is returned by Submit when a job with the same ID is
+// already queued, running, or completed within the dedup window
+type DuplicateJobError struct {
+	ID     string
+	Status JobStatus
+}
+
+func (e *DuplicateJobError) Error() string {
+	return fmt.Sprintf("job %s is already %s", e.ID, e.Status)
+}
+
+type trackedJob struct {
+	status     JobStatus
+	finishedAt time.Time
+}
+
+// dedupTracker remembers job IDs so repeated submissions can be detected.
+// Failed and abandoned jobs are forgotten so that they can be resubmitted;
+// this includes jobs that panicked and jobs dropped by a pool resize or a
+// drain, so only queued, running and recently completed IDs stay reserved.
+type dedupTracker struct {
+	mu      sync.Mutex
+	entries map[string]trackedJob
+}
+
+func newDedupTracker() *dedupTracker {
+	return &dedupTracker{entries: make(map[string]trackedJob)}
+}
+
+// reserve marks id as queued unless it is already known, in which case the
+// existing status is returned with ok set to false
+func (t *dedupTracker) reserve(id string, window time.Duration) (JobStatus, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if entry, exists := t.entries[id]; exists && !entry.expired(window) {
+		return entry.status, false
+	}
+	t.entries[id] = trackedJob{status: StatusQueued}
+	return StatusQueued, true
+}
+
+// status returns the tracked status of id, if any
+func (t *dedupTracker) status(id string, window time.Duration) (JobStatus, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	entry, exists := t.entries[id]
+	if !exists || entry.expired(window) {
+		return "", false
+	}
+	return entry.status, true
+}
+
+// running marks a tracked job as picked up by a worker
+func (t *dedupTracker) running(id string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if _, exists := t.entries[id]; exists {
+		t.entries[id] = trackedJob{status: StatusRunning}
+	}
+}
+
+// complete marks a tracked job as finished, starting its dedup window
+func (t *dedupTracker) complete(id string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if _, exists := t.entries[id]; exists {
+		t.entries[id] = trackedJob{status: StatusCompleted, finishedAt: time.Now()}
+	}
+}
+
+// forget drops id so that it may be submitted again
+func (t *dedupTracker) forget(id string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.entries, id)
+}
+
+// prune removes completed jobs whose window has passed
+func (t *dedupTracker) prune(window time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for id, entry := range t.entries {
+		if entry.expired(window) {
+			delete(t.entries, id)
+		}
+	}
+}
+
+func (e trackedJob) expired(window time.Duration) bool {
+	return e.status == StatusCompleted && time.Since(e.finishedAt) > window
+}
@@ -0,0 +1,156 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"kln-test/internal/config"
+)
+
+func TestSubmitRejectsDuplicates(t *testing.T) {
+	cfg := testConfig(1, 5, "")
+	cfg.Worker.Dedup.Enabled = true
+	cfg.Worker.Dedup.Window = 60
+	pool := NewPool[int](cfg)
+
+	release := make(chan struct{})
+	job := Job[int]{
+		ID: "consumer-1",
+		Process: func(ctx context.Context, n int) error {
+			<-release
+			return nil
+		},
+	}
+
+	if err := pool.Submit(job); err != nil {
+		t.Fatalf("Unexpected submit error: %v", err)
+	}
+
+	var duplicate *DuplicateJobError
+	if err := pool.Submit(job); !errors.As(err, &duplicate) {
+		t.Fatalf("Expected DuplicateJobError, got %v", err)
+	}
+
+	if err := pool.Submit(Job[int]{ID: "consumer-2", Process: job.Process}); err != nil {
+		t.Errorf("Unexpected submit error for a different ID: %v", err)
+	}
+
+	close(release)
+	pool.Drain(context.Background())
+
+	status, ok := pool.Status("consumer-1")
+	if !ok || status != StatusCompleted {
+		t.Errorf("Expected completed status, got %q (tracked: %t)", status, ok)
+	}
+}
+
+func TestDedupTrackerWindow(t *testing.T) {
+	tracker := newDedupTracker()
+
+	if _, ok := tracker.reserve("a", time.Minute); !ok {
+		t.Fatal("Expected first reservation to succeed")
+	}
+	tracker.complete("a")
+
+	if status, ok := tracker.reserve("a", time.Minute); ok || status != StatusCompleted {
+		t.Errorf("Expected completed duplicate within window, got %q (ok: %t)", status, ok)
+	}
+	if _, ok := tracker.reserve("a", 0); !ok {
+		t.Error("Expected reservation to succeed once the window has passed")
+	}
+
+	tracker.forget("a")
+	if _, ok := tracker.status("a", time.Minute); ok {
+		t.Error("Expected forgotten job to be untracked")
+	}
+}
+
+func TestPanickingJobReleasesID(t *testing.T) {
+	cfg := testConfig(1, 5, "")
+	cfg.Worker.Dedup.Enabled = true
+	cfg.Worker.Dedup.Window = 60
+	pool := NewPool[int](cfg)
+	defer pool.Drain(context.Background())
+
+	done := make(chan struct{})
+	err := pool.Submit(Job[int]{
+		ID: "panics",
+		Process: func(ctx context.Context, n int) error {
+			defer close(done)
+			panic("boom")
+		},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected submit error: %v", err)
+	}
+	<-done
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		if _, tracked := pool.Status("panics"); !tracked {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Expected the panicked job's ID to be released")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if err := pool.Submit(Job[int]{ID: "panics", Process: func(ctx context.Context, n int) error { return nil }}); err != nil {
+		t.Errorf("Expected resubmission to be accepted, got %v", err)
+	}
+}
+
+func TestResizeKeepsQueuedJobs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	writeConfig := func(poolSize, queueSize int) {
+		data := fmt.Sprintf(`{"worker":{"poolSize":%d,"queueSize":%d,"retry":{"maxAttempts":1,"initialTimeout":5,"maxTimeout":5},"dedup":{"enabled":true,"window":60}}}`, poolSize, queueSize)
+		if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeConfig(1, 1)
+	cfg, err := config.Load(path)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	pool := NewPool[int](cfg)
+
+	release := make(chan struct{})
+	var processed atomic.Int32
+	process := func(ctx context.Context, n int) error {
+		<-release
+		processed.Add(1)
+		return nil
+	}
+	started := make(chan struct{})
+	pool.Submit(Job[int]{ID: "running", Process: func(ctx context.Context, n int) error {
+		close(started)
+		return process(ctx, n)
+	}})
+	<-started
+	if err := pool.Submit(Job[int]{ID: "queued", Process: process}); err != nil {
+		t.Fatalf("Unexpected submit error: %v", err)
+	}
+
+	// The resize waits for the running job, so let it finish meanwhile
+	writeConfig(2, 4)
+	cfg.Reload()
+	close(release)
+	if err := pool.Submit(Job[int]{ID: "after-resize", Process: process}); err != nil {
+		t.Fatalf("Unexpected submit error: %v", err)
+	}
+	pool.Drain(context.Background())
+
+	if got := processed.Load(); got != 3 {
+		t.Errorf("Expected the queued job to survive the resize, got %d processed", got)
+	}
+	if status, _ := pool.Status("queued"); status != StatusCompleted {
+		t.Errorf("Expected the queued job to complete, got %q", status)
+	}
+}
@@ -0,0 +1,157 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"time"
+)
+
+// DrainSummary reports what happened to the pool's work during a drain
+type DrainSummary struct {
+	Completed    int
+	Failed       int
+	DeadLettered int
+	TimedOut     bool
+}
+
+// deadLetter is the persisted form of a job that could not be finished
+type deadLetter[T any] struct {
+	ID      string    `json:"id"`
+	Payload T         `json:"payload"`
+	Reason  string    `json:"reason"`
+	Time    time.Time `json:"time"`
+}
+
+// Drain stops accepting new jobs and waits for queued and in-flight jobs to
+// finish. If ctx expires first, in-flight jobs are interrupted and every job
+// left over is written to the configured dead-letter file.
+func (p *Pool[T]) Drain(ctx context.Context) DrainSummary {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return DrainSummary{}
+	}
+	p.closed = true
+	batches := p.batcher.takeAll()
+	p.mu.Unlock()
+
+	completed, failed := p.completed.Load(), p.failed.Load()
+	log.Printf("Draining worker pool: %d jobs queued, %d batches pending", len(p.jobs), len(batches))
+
+	// Partial batches still get processed; nothing else sends once closed is set
+	for _, batch := range batches {
+		select {
+		case p.jobs <- batch:
+		case <-ctx.Done():
+			p.abandon(batch, "not started before shutdown")
+		}
+	}
+	close(p.jobs)
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+
+	var summary DrainSummary
+	select {
+	case <-done:
+	case <-ctx.Done():
+		log.Printf("Worker pool drain timed out, interrupting in-flight jobs")
+		summary.TimedOut = true
+		p.abortFunc()
+		<-done
+	}
+	p.cancelFunc()
+
+	// Whatever is still queued was never picked up by a worker
+	for job := range p.jobs {
+		p.abandon(job, "not started before shutdown")
+	}
+
+	summary.Completed = int(p.completed.Load() - completed)
+	summary.Failed = int(p.failed.Load() - failed)
+	summary.DeadLettered = p.flushDeadLetters()
+
+	log.Printf("Worker pool drained: %d completed, %d failed, %d dead-lettered (timed out: %t)",
+		summary.Completed, summary.Failed, summary.DeadLettered, summary.TimedOut)
+	return summary
+}
+
+// abandon records a job that will not be completed by this pool
+func (p *Pool[T]) abandon(job Job[T], reason string) {
+	if job.batch != nil {
+		for _, member := range job.batch {
+			p.abandon(member, reason)
+		}
+		return
+	}
+	p.tracker.forget(job.ID)
+
+	if job.stored {
+		// The coordinator releases the lease, so another instance runs it
+		log.Printf("Returning job %s to the shared store: %s", job.ID, reason)
+		job.notify(outcomeAborted)
+		return
+	}
+
+	p.deadMu.Lock()
+	p.deadLetters = append(p.deadLetters, deadLetter[T]{
+		ID:      job.ID,
+		Payload: job.Payload,
+		Reason:  reason,
+		Time:    time.Now(),
+	})
+	p.deadMu.Unlock()
+
+	job.notify(outcomeAborted)
+}
+
+// flushDeadLetters persists abandoned jobs and returns how many there were
+func (p *Pool[T]) flushDeadLetters() int {
+	p.deadMu.Lock()
+	defer p.deadMu.Unlock()
+
+	letters := p.deadLetters
+	p.deadLetters = nil
+	if len(letters) == 0 {
+		return 0
+	}
+
+	path := p.cfg.GetWorkerConfig().Shutdown.DeadLetterFile
+	if path == "" {
+		for _, letter := range letters {
+			log.Printf("Dead-lettered job %s: %s", letter.ID, letter.Reason)
+		}
+		return len(letters)
+	}
+
+	if err := appendDeadLetters(path, letters); err != nil {
+		log.Printf("Failed to persist %d dead-lettered jobs: %v", len(letters), err)
+		for _, letter := range letters {
+			log.Printf("Dead-lettered job %s: %s", letter.ID, letter.Reason)
+		}
+	}
+	return len(letters)
+}
+
+// appendDeadLetters writes one JSON document per line to path
+func appendDeadLetters[T any](path string, letters []deadLetter[T]) error {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	encoder := json.NewEncoder(file)
+	for _, letter := range letters {
+		if err := encoder.Encode(letter); err != nil {
+			return fmt.Errorf("failed to encode job %s: %w", letter.ID, err)
+		}
+	}
+	return file.Sync()
+}
@@ -0,0 +1,108 @@
+package worker
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"kln-test/internal/config"
+)
+
+func testConfig(poolSize, queueSize int, deadLetterFile string) *config.Config {
+	return &config.Config{
+		Worker: config.WorkerConfig{
+			PoolSize:  poolSize,
+			QueueSize: queueSize,
+			Retry: config.WorkerRetryConfig{
+				MaxAttempts:    1,
+				InitialTimeout: 5,
+				MaxTimeout:     5,
+			},
+			Shutdown: config.WorkerShutdownConfig{
+				DeadLetterFile: deadLetterFile,
+			},
+		},
+	}
+}
+
+func TestDrainCompletesQueuedJobs(t *testing.T) {
+	pool := NewPool[int](testConfig(1, 5, ""))
+
+	var processed atomic.Int32
+	for i := 0; i < 3; i++ {
+		err := pool.Submit(Job[int]{
+			ID:      "job",
+			Payload: i,
+			Process: func(ctx context.Context, n int) error {
+				time.Sleep(10 * time.Millisecond)
+				processed.Add(1)
+				return nil
+			},
+		})
+		if err != nil {
+			t.Fatalf("Unexpected submit error: %v", err)
+		}
+	}
+
+	summary := pool.Drain(context.Background())
+	if summary.Completed != 3 || processed.Load() != 3 {
+		t.Errorf("Expected 3 completed jobs, got summary %+v and %d processed", summary, processed.Load())
+	}
+	if summary.TimedOut || summary.DeadLettered != 0 {
+		t.Errorf("Unexpected summary: %+v", summary)
+	}
+
+	if err := pool.Submit(Job[int]{ID: "late"}); err != ErrPoolClosed {
+		t.Errorf("Expected ErrPoolClosed after drain, got %v", err)
+	}
+}
+
+func TestDrainDeadLettersLeftoverJobs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dead.jsonl")
+	pool := NewPool[int](testConfig(1, 5, path))
+
+	blocking := func(ctx context.Context, n int) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	for i := 0; i < 3; i++ {
+		if err := pool.Submit(Job[int]{ID: "job", Payload: i, Process: blocking}); err != nil {
+			t.Fatalf("Unexpected submit error: %v", err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	summary := pool.Drain(ctx)
+
+	if !summary.TimedOut {
+		t.Error("Expected drain to time out")
+	}
+	if summary.DeadLettered != 3 {
+		t.Errorf("Expected 3 dead-lettered jobs, got %d", summary.DeadLettered)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Failed to open dead-letter file: %v", err)
+	}
+	defer file.Close()
+
+	var lines int
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var letter deadLetter[int]
+		if err := json.Unmarshal(scanner.Bytes(), &letter); err != nil {
+			t.Fatalf("Invalid dead-letter line: %v", err)
+		}
+		lines++
+	}
+	if lines != 3 {
+		t.Errorf("Expected 3 dead-letter lines, got %d", lines)
+	}
+}
@@ -3,9 +3,11 @@ package worker
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log"
 	"math"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"kln-test/internal/config"
@@ -16,6 +18,20 @@ type Job[T any] struct {
 	ID      string
 	Payload T
 	Process func(context.Context, T) error
+
+	// BatchKey groups jobs that can be processed together when the pool
+	// has a batch processor and batching is configured
+	BatchKey string
+
+	// batch holds the member jobs when this job is a flushed batch
+	batch []Job[T]
+
+	// done is notified of the final outcome, used to drive workflows
+	done func(outcome)
+
+	// stored is set for jobs claimed from a shared store. When abandoned
+	// they are released back to the store instead of being dead-lettered.
+	stored bool
 }
 
 // Pool manages a pool of workers and a job queue
@@ -27,29 +43,53 @@ type Pool[T any] struct {
 	ctx        context.Context
 	cancelFunc context.CancelFunc
 	mu         sync.RWMutex
+
+	// abortCtx outlives restarts caused by resizing and is only cancelled
+	// when a drain runs out of time, interrupting in-flight jobs
+	abortCtx  context.Context
+	abortFunc context.CancelFunc
+	closed    bool
+
+	completed atomic.Int64
+	failed    atomic.Int64
+
+	deadMu      sync.Mutex
+	deadLetters []deadLetter[T]
+
+	tracker *dedupTracker
+	batcher *batcher[T]
+
+	workflowsMu sync.Mutex
+	workflows   map[string]*workflowRun[T]
 }
 
 // NewPool creates a new worker pool
 func NewPool[T any](cfg *config.Config) *Pool[T] {
 	p := &Pool[T]{
-		cfg: cfg,
+		cfg:       cfg,
+		tracker:   newDedupTracker(),
+		batcher:   newBatcher[T](),
+		workflows: make(map[string]*workflowRun[T]),
 	}
+	p.abortCtx, p.abortFunc = context.WithCancel(context.Background())
 	p.Start()
 
 	return p
 }
 
 // watchConfig monitors configuration changes and scales the pool accordingly
-func (p *Pool[T]) watchConfig() {
+func (p *Pool[T]) watchConfig(ctx context.Context) {
 	ticker := time.NewTicker(5 * time.Second)
 	defer ticker.Stop()
 
 	for {
 		select {
-		case <-p.ctx.Done():
+		case <-ctx.Done():
 			return
 		case <-ticker.C:
 			p.scalePoolIfNeeded()
+			p.tracker.prune(p.dedupWindow())
+			p.pruneWorkflows()
 		}
 	}
 }
@@ -61,23 +101,87 @@ func (p *Pool[T]) scalePoolIfNeeded() {
 	if workerConfig.QueueSize != cap(p.jobs) || workerConfig.PoolSize != p.workers {
 		p.mu.Lock()
 		defer p.mu.Unlock()
+		if p.closed {
+			return
+		}
 		log.Printf("Resizing worker pool from %d to %d", p.workers, workerConfig.PoolSize)
 		log.Printf("Resizing worker queue from %d to %d", cap(p.jobs), workerConfig.QueueSize)
 		p.Shutdown()
+		queued := p.takeQueued()
 		p.Start()
+		p.requeue(queued)
 	}
 }
 
+// takeQueued empties the job queue of a pool that has been shut down
+func (p *Pool[T]) takeQueued() []Job[T] {
+	var queued []Job[T]
+	for job := range p.jobs {
+		queued = append(queued, job)
+	}
+	return queued
+}
+
+// requeue moves jobs left over from a resize into the new queue. Jobs that
+// no longer fit are abandoned, which also frees their IDs for resubmission.
+func (p *Pool[T]) requeue(jobs []Job[T]) {
+	for _, job := range jobs {
+		select {
+		case p.jobs <- job:
+		default:
+			p.abandon(job, "dropped by pool resize")
+		}
+	}
+}
+
+// ErrPoolClosed is returned when submitting to a pool that is draining
+var ErrPoolClosed = errors.New("worker pool is shutting down")
+
 // Submit adds a job to the queue
 func (p *Pool[T]) Submit(job Job[T]) error {
 	p.scalePoolIfNeeded()
 
-	select {
-	case p.jobs <- job:
-		return nil
-	default:
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.closed {
+		return ErrPoolClosed
+	}
+
+	dedup := p.cfg.GetWorkerConfig().Dedup.Enabled
+	if dedup {
+		if status, ok := p.tracker.reserve(job.ID, p.dedupWindow()); !ok {
+			return &DuplicateJobError{ID: job.ID, Status: status}
+		}
+	}
+
+	var accepted bool
+	if p.batching(job) {
+		accepted = p.addToBatch(job)
+	} else {
+		select {
+		case p.jobs <- job:
+			accepted = true
+		default:
+		}
+	}
+
+	if !accepted {
+		if dedup {
+			p.tracker.forget(job.ID)
+		}
 		return errors.New("job queue is full")
 	}
+	return nil
+}
+
+// Status reports the status of a job tracked for deduplication
+func (p *Pool[T]) Status(id string) (JobStatus, bool) {
+	return p.tracker.status(id, p.dedupWindow())
+}
+
+// dedupWindow returns how long completed job IDs are remembered
+func (p *Pool[T]) dedupWindow() time.Duration {
+	return time.Duration(p.cfg.GetWorkerConfig().Dedup.Window) * time.Second
 }
 
 // Start start the worker pool
@@ -95,7 +199,7 @@ func (p *Pool[T]) Start() {
 		go p.startWorker(i)
 	}
 
-	go p.watchConfig()
+	go p.watchConfig(ctx)
 }
 
 // Shutdown gracefully shuts down the worker pool
@@ -112,6 +216,8 @@ func (p *Pool[T]) startWorker(id int) {
 		select {
 		case <-p.ctx.Done():
 			return
+		case <-p.abortCtx.Done():
+			return
 		case job, ok := <-p.jobs:
 			if !ok {
 				return
@@ -121,24 +227,76 @@ func (p *Pool[T]) startWorker(id int) {
 	}
 }
 
+// outcome is the final result of running a job through the retry loop
+type outcome int
+
+const (
+	outcomeCompleted outcome = iota
+	outcomeFailed
+	outcomeAborted
+)
+
 func (p *Pool[T]) processJobWithRetry(workerID int, job Job[T]) {
-	p.mu.RLock()
+	if job.batch != nil {
+		p.processBatchWithRetry(workerID, job)
+		return
+	}
+
+	p.tracker.running(job.ID)
+
+	switch p.retry(workerID, job.ID, func(ctx context.Context) error {
+		return job.Process(ctx, job.Payload)
+	}) {
+	case outcomeCompleted:
+		p.completed.Add(1)
+		p.tracker.complete(job.ID)
+		job.notify(outcomeCompleted)
+	case outcomeFailed:
+		p.failed.Add(1)
+		p.tracker.forget(job.ID)
+		job.notify(outcomeFailed)
+	case outcomeAborted:
+		p.abandon(job, "interrupted by shutdown")
+	}
+}
+
+// notify reports the job's final outcome to whoever is waiting on it
+func (j Job[T]) notify(result outcome) {
+	if j.done != nil {
+		j.done(result)
+	}
+}
+
+// retry runs attempt until it succeeds, the retry budget is exhausted, or
+// the pool is aborted, backing off exponentially between attempts
+func (p *Pool[T]) retry(workerID int, jobID string, attempt func(context.Context) error) outcome {
+	// Config has its own lock; taking p.mu here would deadlock against a
+	// resize waiting for this worker to exit
 	retry := p.cfg.GetWorkerConfig().Retry
-	p.mu.RUnlock()
 
 	timeout := time.Duration(retry.InitialTimeout) * time.Second
 	maxTimeOut := time.Duration(retry.MaxTimeout) * time.Second
 
-	for attempt := 1; attempt <= retry.MaxAttempts; attempt++ {
-		log.Printf("Worker %d processing job %s (attempt %d/%d)", workerID, job.ID, attempt, retry.MaxAttempts)
+	for try := 1; try <= retry.MaxAttempts; try++ {
+		if p.abortCtx.Err() != nil {
+			return outcomeAborted
+		}
+		log.Printf("Worker %d processing job %s (attempt %d/%d)", workerID, jobID, try, retry.MaxAttempts)
 
 		// Create a context with timeout for this attempt
-		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		ctx, cancel := context.WithTimeout(p.abortCtx, timeout)
 
 		// Run the job with timeout
 		done := make(chan error, 1)
 		go func() {
-			done <- job.Process(ctx, job.Payload)
+			// A panicking job fails the attempt instead of the process, so
+			// that its ID is released like any other failure
+			defer func() {
+				if r := recover(); r != nil {
+					done <- fmt.Errorf("job panicked: %v", r)
+				}
+			}()
+			done <- attempt(ctx)
 		}()
 
 		// Wait for job completion or timeout
@@ -146,17 +304,21 @@ func (p *Pool[T]) processJobWithRetry(workerID int, job Job[T]) {
 		case err := <-done:
 			cancel()
 			if err == nil {
-				log.Printf("Worker %d successfully completed job %s", workerID, job.ID)
-				return
+				log.Printf("Worker %d successfully completed job %s", workerID, jobID)
+				return outcomeCompleted
 			}
-			log.Printf("Worker %d failed job %s: %v", workerID, job.ID, err)
+			log.Printf("Worker %d failed job %s: %v", workerID, jobID, err)
 		case <-ctx.Done():
 			cancel()
-			log.Printf("Worker %d still processing job %s (attempt %d/%d)", workerID, job.ID, attempt, retry.MaxAttempts)
+			log.Printf("Worker %d still processing job %s (attempt %d/%d)", workerID, jobID, try, retry.MaxAttempts)
+		}
+
+		if p.abortCtx.Err() != nil {
+			return outcomeAborted
 		}
 
 		// Calculate next timeout with exponential backoff
-		backoffTime := timeout * time.Duration(math.Pow(2, float64(attempt-1)))
+		backoffTime := timeout * time.Duration(math.Pow(2, float64(try-1)))
 		if backoffTime > maxTimeOut {
 			timeout = maxTimeOut
 		} else {
@@ -164,12 +326,16 @@ func (p *Pool[T]) processJobWithRetry(workerID int, job Job[T]) {
 		}
 
 		// If this was the last attempt, log failure
-		if attempt == retry.MaxAttempts {
-			log.Printf("Worker %d gave up on job %s after %d attempts", workerID, job.ID, retry.MaxAttempts)
-			return
+		if try == retry.MaxAttempts {
+			log.Printf("Worker %d gave up on job %s after %d attempts", workerID, jobID, retry.MaxAttempts)
+			return outcomeFailed
 		}
 
-		// Wait before retrying
-		time.Sleep(timeout)
+		// Wait before retrying, unless the pool is being torn down
+		select {
+		case <-time.After(timeout):
+		case <-p.abortCtx.Done():
+		}
 	}
+	return outcomeFailed
 }
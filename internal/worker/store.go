@@ -0,0 +1,311 @@
+package worker
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrJobExists is returned when enqueuing a job ID that is already stored
+	ErrJobExists = errors.New("job already exists in store")
+	// ErrLeaseLost is returned when a lease has expired and been claimed elsewhere
+	ErrLeaseLost = errors.New("lease lost")
+)
+
+// FileStore is a job store in a directory shared by several processes.
+// A process claims a job by writing a lease file that it renews with
+// heartbeats; a lease that is not renewed before it expires can be claimed
+// by any other process, so work is not lost when its owner dies.
+//
+// Layout:
+//
+//	<dir>/jobs/<id>.json     queued job and its payload
+//	<dir>/leases/<id>.lease  current owner and expiry of a claimed job
+//	<dir>/store.lock         flock serialising claims across processes
+type FileStore[T any] struct {
+	dir   string
+	owner string
+	ttl   time.Duration
+}
+
+// Lease is a claim on a stored job held by this process
+type Lease[T any] struct {
+	ID      string
+	Payload T
+
+	store *FileStore[T]
+}
+
+type storedJob[T any] struct {
+	ID         string    `json:"id"`
+	Payload    T         `json:"payload"`
+	EnqueuedAt time.Time `json:"enqueuedAt"`
+}
+
+type leaseRecord struct {
+	Owner     string    `json:"owner"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// NewFileStore opens or creates a store in dir. owner must be unique per
+// process and ttl is how long a lease is valid without a heartbeat.
+func NewFileStore[T any](dir, owner string, ttl time.Duration) (*FileStore[T], error) {
+	if ttl <= 0 {
+		return nil, fmt.Errorf("lease TTL must be positive, got %s", ttl)
+	}
+	for _, sub := range []string{"jobs", "leases"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create store directory: %w", err)
+		}
+	}
+	return &FileStore[T]{dir: dir, owner: owner, ttl: ttl}, nil
+}
+
+// Enqueue stores a new job so that any process sharing the store may claim it
+func (s *FileStore[T]) Enqueue(id string, payload T) error {
+	data, err := json.Marshal(storedJob[T]{ID: id, Payload: payload, EnqueuedAt: time.Now()})
+	if err != nil {
+		return fmt.Errorf("failed to encode job %s: %w", id, err)
+	}
+
+	temp, err := s.writeTemp(data)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(temp)
+
+	// Linking fails if the job already exists, making publication atomic
+	if err := os.Link(temp, s.jobPath(id)); err != nil {
+		if errors.Is(err, os.ErrExist) {
+			return ErrJobExists
+		}
+		return fmt.Errorf("failed to store job %s: %w", id, err)
+	}
+	return nil
+}
+
+// Status reports whether a stored job is waiting to be claimed or is held
+// under a live lease by some process. It returns "" if the job is no
+// longer stored, for example because it has been completed.
+func (s *FileStore[T]) Status(id string) (JobStatus, error) {
+	unlock, err := s.lock()
+	if err != nil {
+		return "", err
+	}
+	defer unlock()
+
+	if _, err := os.Stat(s.jobPath(id)); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to read job %s: %w", id, err)
+	}
+	if lease, err := s.readLease(id); err == nil && time.Now().Before(lease.ExpiresAt) {
+		return StatusRunning, nil
+	}
+	return StatusQueued, nil
+}
+
+// Claim leases the oldest unclaimed job, or a job whose lease has expired.
+// It returns nil when there is nothing to claim.
+func (s *FileStore[T]) Claim() (*Lease[T], error) {
+	unlock, err := s.lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	entries, err := os.ReadDir(filepath.Join(s.dir, "jobs"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list jobs: %w", err)
+	}
+
+	var oldest *storedJob[T]
+	for _, entry := range entries {
+		name := entry.Name()
+		if !strings.HasSuffix(name, ".json") {
+			continue
+		}
+		id, err := decodeName(strings.TrimSuffix(name, ".json"))
+		if err != nil {
+			continue
+		}
+
+		if lease, err := s.readLease(id); err == nil && time.Now().Before(lease.ExpiresAt) {
+			continue
+		}
+
+		job, err := s.readJob(id)
+		if err != nil {
+			// Completed by another process since the listing
+			continue
+		}
+		if oldest == nil || job.EnqueuedAt.Before(oldest.EnqueuedAt) {
+			oldest = job
+		}
+	}
+
+	if oldest == nil {
+		return nil, nil
+	}
+	if err := s.writeLease(oldest.ID); err != nil {
+		return nil, err
+	}
+	return &Lease[T]{ID: oldest.ID, Payload: oldest.Payload, store: s}, nil
+}
+
+// Heartbeat extends the lease, failing with ErrLeaseLost if it has been
+// claimed by another process in the meantime
+func (l *Lease[T]) Heartbeat() error {
+	unlock, err := l.store.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if err := l.store.checkOwner(l.ID); err != nil {
+		return err
+	}
+	return l.store.writeLease(l.ID)
+}
+
+// Complete removes the job from the store
+func (l *Lease[T]) Complete() error {
+	unlock, err := l.store.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if err := l.store.checkOwner(l.ID); err != nil {
+		return err
+	}
+	if err := os.Remove(l.store.jobPath(l.ID)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove job %s: %w", l.ID, err)
+	}
+	return os.Remove(l.store.leasePath(l.ID))
+}
+
+// Release gives up the lease so that the job can be claimed again straight away
+func (l *Lease[T]) Release() error {
+	unlock, err := l.store.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if err := l.store.checkOwner(l.ID); err != nil {
+		return err
+	}
+	return os.Remove(l.store.leasePath(l.ID))
+}
+
+// lock takes the store-wide lock shared by all processes
+func (s *FileStore[T]) lock() (func(), error) {
+	file, err := os.OpenFile(filepath.Join(s.dir, "store.lock"), os.O_CREATE|os.O_RDWR, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open store lock: %w", err)
+	}
+	if err := lockFile(file); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to lock store: %w", err)
+	}
+	return func() {
+		unlockFile(file)
+		file.Close()
+	}, nil
+}
+
+func (s *FileStore[T]) checkOwner(id string) error {
+	lease, err := s.readLease(id)
+	if err != nil || lease.Owner != s.owner {
+		return ErrLeaseLost
+	}
+	return nil
+}
+
+func (s *FileStore[T]) readJob(id string) (*storedJob[T], error) {
+	data, err := os.ReadFile(s.jobPath(id))
+	if err != nil {
+		return nil, err
+	}
+	var job storedJob[T]
+	if err := json.Unmarshal(data, &job); err != nil {
+		return nil, fmt.Errorf("failed to decode job %s: %w", id, err)
+	}
+	return &job, nil
+}
+
+func (s *FileStore[T]) readLease(id string) (*leaseRecord, error) {
+	data, err := os.ReadFile(s.leasePath(id))
+	if err != nil {
+		return nil, err
+	}
+	var lease leaseRecord
+	if err := json.Unmarshal(data, &lease); err != nil {
+		return nil, fmt.Errorf("failed to decode lease %s: %w", id, err)
+	}
+	return &lease, nil
+}
+
+// writeLease records this process as owner of id until the TTL expires.
+// The caller must hold the store lock.
+func (s *FileStore[T]) writeLease(id string) error {
+	data, err := json.Marshal(leaseRecord{Owner: s.owner, ExpiresAt: time.Now().Add(s.ttl)})
+	if err != nil {
+		return err
+	}
+
+	temp, err := s.writeTemp(data)
+	if err != nil {
+		return err
+	}
+	if err := os.Rename(temp, s.leasePath(id)); err != nil {
+		os.Remove(temp)
+		return fmt.Errorf("failed to write lease for job %s: %w", id, err)
+	}
+	return nil
+}
+
+// writeTemp writes data to a temporary file inside the store
+func (s *FileStore[T]) writeTemp(data []byte) (string, error) {
+	file, err := os.CreateTemp(s.dir, ".tmp-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(data); err != nil {
+		os.Remove(file.Name())
+		return "", fmt.Errorf("failed to write temporary file: %w", err)
+	}
+	if err := file.Sync(); err != nil {
+		os.Remove(file.Name())
+		return "", fmt.Errorf("failed to sync temporary file: %w", err)
+	}
+	return file.Name(), nil
+}
+
+func (s *FileStore[T]) jobPath(id string) string {
+	return filepath.Join(s.dir, "jobs", encodeName(id)+".json")
+}
+
+func (s *FileStore[T]) leasePath(id string) string {
+	return filepath.Join(s.dir, "leases", encodeName(id)+".lease")
+}
+
+// encodeName turns an arbitrary job ID into a safe file name
+func encodeName(id string) string {
+	return hex.EncodeToString([]byte(id))
+}
+
+func decodeName(name string) (string, error) {
+	id, err := hex.DecodeString(name)
+	return string(id), err
+}
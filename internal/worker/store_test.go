@@ -0,0 +1,274 @@
+package worker
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestStoreHelperProcess is not a real test: it is run as a separate process
+// by the tests below to claim jobs from a shared store
+func TestStoreHelperProcess(t *testing.T) {
+	dir := os.Getenv("STORE_HELPER_DIR")
+	if dir == "" {
+		t.Skip("only run as a helper process")
+	}
+
+	store, err := NewFileStore[int](dir, os.Getenv("STORE_HELPER_OWNER"), time.Second)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	for {
+		lease, err := store.Claim()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		if lease == nil {
+			os.Exit(0)
+		}
+		fmt.Println(lease.ID)
+
+		// Simulate a crash by exiting while holding the lease
+		if os.Getenv("STORE_HELPER_MODE") == "crash" {
+			os.Exit(0)
+		}
+		if err := lease.Complete(); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+}
+
+func startStoreHelper(t *testing.T, dir, owner, mode string) (*exec.Cmd, *bytes.Buffer) {
+	t.Helper()
+
+	var stdout bytes.Buffer
+	cmd := exec.Command(os.Args[0], "-test.run=^TestStoreHelperProcess$")
+	cmd.Env = append(os.Environ(),
+		// Skip the race detector's exit delay so leases have not expired yet
+		"GORACE=atexit_sleep_ms=0",
+		"STORE_HELPER_DIR="+dir,
+		"STORE_HELPER_OWNER="+owner,
+		"STORE_HELPER_MODE="+mode,
+	)
+	cmd.Stdout = &stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("Failed to start helper process: %v", err)
+	}
+	return cmd, &stdout
+}
+
+func TestFileStoreClaimsAreExclusiveAcrossProcesses(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewFileStore[int](dir, "parent", time.Minute)
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+
+	const jobs = 30
+	for i := 0; i < jobs; i++ {
+		if err := store.Enqueue(fmt.Sprintf("job-%d", i), i); err != nil {
+			t.Fatalf("Failed to enqueue: %v", err)
+		}
+	}
+	if err := store.Enqueue("job-0", 0); !errors.Is(err, ErrJobExists) {
+		t.Errorf("Expected ErrJobExists for a repeated ID, got %v", err)
+	}
+
+	var outputs []*bytes.Buffer
+	var cmds []*exec.Cmd
+	for i := 0; i < 3; i++ {
+		cmd, out := startStoreHelper(t, dir, fmt.Sprintf("helper-%d", i), "complete")
+		cmds = append(cmds, cmd)
+		outputs = append(outputs, out)
+	}
+	for _, cmd := range cmds {
+		if err := cmd.Wait(); err != nil {
+			t.Fatalf("Helper process failed: %v", err)
+		}
+	}
+
+	claimed := make(map[string]int)
+	for _, out := range outputs {
+		scanner := bufio.NewScanner(out)
+		for scanner.Scan() {
+			claimed[scanner.Text()]++
+		}
+	}
+	if len(claimed) != jobs {
+		t.Errorf("Expected %d distinct jobs to be claimed, got %d", jobs, len(claimed))
+	}
+	for id, n := range claimed {
+		if n != 1 {
+			t.Errorf("Job %s was claimed %d times", id, n)
+		}
+	}
+
+	if lease, err := store.Claim(); err != nil || lease != nil {
+		t.Errorf("Expected an empty store, got %v (err: %v)", lease, err)
+	}
+}
+
+func TestFileStoreReclaimsExpiredLeases(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewFileStore[int](dir, "survivor", time.Minute)
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	if err := store.Enqueue("orphan", 42); err != nil {
+		t.Fatalf("Failed to enqueue: %v", err)
+	}
+
+	cmd, _ := startStoreHelper(t, dir, "crasher", "crash")
+	if err := cmd.Wait(); err != nil {
+		t.Fatalf("Helper process failed: %v", err)
+	}
+
+	if lease, err := store.Claim(); err != nil || lease != nil {
+		t.Fatalf("Expected the job to still be leased, got %v (err: %v)", lease, err)
+	}
+
+	time.Sleep(1100 * time.Millisecond)
+
+	lease, err := store.Claim()
+	if err != nil || lease == nil {
+		t.Fatalf("Expected to reclaim the expired lease, got %v (err: %v)", lease, err)
+	}
+	if lease.ID != "orphan" || lease.Payload != 42 {
+		t.Errorf("Unexpected lease: %+v", lease)
+	}
+	if err := lease.Heartbeat(); err != nil {
+		t.Errorf("Unexpected heartbeat error: %v", err)
+	}
+	if err := lease.Complete(); err != nil {
+		t.Errorf("Unexpected complete error: %v", err)
+	}
+}
+
+func TestLeaseLostAfterExpiry(t *testing.T) {
+	dir := t.TempDir()
+	first, _ := NewFileStore[int](dir, "first", 50*time.Millisecond)
+	second, _ := NewFileStore[int](dir, "second", time.Minute)
+
+	if err := first.Enqueue("job", 1); err != nil {
+		t.Fatalf("Failed to enqueue: %v", err)
+	}
+	lease, err := first.Claim()
+	if err != nil || lease == nil {
+		t.Fatalf("Expected a lease, got %v (err: %v)", lease, err)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	if stolen, err := second.Claim(); err != nil || stolen == nil {
+		t.Fatalf("Expected second owner to claim the expired job, got %v (err: %v)", stolen, err)
+	}
+
+	if err := lease.Heartbeat(); !errors.Is(err, ErrLeaseLost) {
+		t.Errorf("Expected ErrLeaseLost, got %v", err)
+	}
+}
+
+func TestFileStoreStatus(t *testing.T) {
+	dir := t.TempDir()
+	first, _ := NewFileStore[int](dir, "first", time.Minute)
+	second, _ := NewFileStore[int](dir, "second", time.Minute)
+
+	if status, err := second.Status("job"); err != nil || status != "" {
+		t.Errorf("Expected no status for an unknown job, got %q (err: %v)", status, err)
+	}
+	if err := first.Enqueue("job", 1); err != nil {
+		t.Fatalf("Failed to enqueue: %v", err)
+	}
+	if status, _ := second.Status("job"); status != StatusQueued {
+		t.Errorf("Expected %q, got %q", StatusQueued, status)
+	}
+
+	lease, err := first.Claim()
+	if err != nil || lease == nil {
+		t.Fatalf("Expected a lease, got %v (err: %v)", lease, err)
+	}
+	if status, _ := second.Status("job"); status != StatusRunning {
+		t.Errorf("Expected %q while another process holds the lease, got %q", StatusRunning, status)
+	}
+
+	if err := lease.Complete(); err != nil {
+		t.Fatalf("Failed to complete: %v", err)
+	}
+	if status, _ := second.Status("job"); status != "" {
+		t.Errorf("Expected no status once completed, got %q", status)
+	}
+}
+
+func TestStoreRejectsNonPositiveIntervals(t *testing.T) {
+	if _, err := NewFileStore[int](t.TempDir(), "owner", 0); err == nil {
+		t.Error("Expected a zero lease TTL to be rejected")
+	}
+
+	store, err := NewFileStore[int](t.TempDir(), "owner", time.Minute)
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	pool := NewPool[int](testConfig(1, 1, ""))
+	defer pool.Drain(context.Background())
+	build := func(id string, n int) Job[int] { return Job[int]{ID: id, Payload: n} }
+	if _, err := NewCoordinator(store, pool, build, 0); err == nil {
+		t.Error("Expected a zero poll interval to be rejected")
+	}
+}
+
+func TestDrainReleasesStoredJobsInsteadOfDeadLettering(t *testing.T) {
+	dir := t.TempDir()
+	deadLetters := filepath.Join(t.TempDir(), "dead.jsonl")
+	store, err := NewFileStore[int](dir, "draining", time.Minute)
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	if err := store.Enqueue("slow", 7); err != nil {
+		t.Fatalf("Failed to enqueue: %v", err)
+	}
+
+	pool := NewPool[int](testConfig(1, 1, deadLetters))
+	started := make(chan struct{})
+	coordinator, err := NewCoordinator(store, pool, func(id string, n int) Job[int] {
+		return Job[int]{ID: id, Payload: n, Process: func(ctx context.Context, n int) error {
+			close(started)
+			<-ctx.Done()
+			return ctx.Err()
+		}}
+	}, time.Hour)
+	if err != nil {
+		t.Fatalf("Failed to create coordinator: %v", err)
+	}
+	ctx, stop := context.WithCancel(context.Background())
+	defer stop()
+	go coordinator.Run(ctx)
+	<-started
+
+	drainCtx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	summary := pool.Drain(drainCtx)
+
+	if summary.DeadLettered != 0 {
+		t.Errorf("Expected no dead letters, got %d", summary.DeadLettered)
+	}
+	if _, err := os.Stat(deadLetters); !os.IsNotExist(err) {
+		t.Errorf("Expected no dead-letter file, got %v", err)
+	}
+
+	other, _ := NewFileStore[int](dir, "other", time.Minute)
+	lease, err := other.Claim()
+	if err != nil || lease == nil || lease.ID != "slow" {
+		t.Fatalf("Expected the job to be claimable by another instance, got %v (err: %v)", lease, err)
+	}
+}
@@ -0,0 +1,412 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// FailurePolicy decides how a workflow reacts when one of its steps fails
+type FailurePolicy int
+
+const (
+	// FailAbort stops scheduling further steps and fails the workflow
+	FailAbort FailurePolicy = iota
+	// FailSkip marks the step as skipped and lets its dependents run
+	FailSkip
+	// FailCompensate fails the workflow and runs the Compensate function of
+	// every completed step, most recently completed first
+	FailCompensate
+)
+
+// StepStatus describes where a workflow step is in its lifecycle
+type StepStatus string
+
+const (
+	StepPending     StepStatus = "pending"
+	StepScheduled   StepStatus = "scheduled"
+	StepCompleted   StepStatus = "completed"
+	StepFailed      StepStatus = "failed"
+	StepSkipped     StepStatus = "skipped"
+	StepCancelled   StepStatus = "cancelled"
+	StepCompensated StepStatus = "compensated"
+)
+
+// WorkflowState describes the overall state of a workflow
+type WorkflowState string
+
+const (
+	WorkflowRunning      WorkflowState = "running"
+	WorkflowCompleted    WorkflowState = "completed"
+	WorkflowFailed       WorkflowState = "failed"
+	WorkflowCompensating WorkflowState = "compensating"
+	WorkflowCompensated  WorkflowState = "compensated"
+)
+
+// workflowRetention is how long finished workflows remain queryable
+const workflowRetention = time.Hour
+
+// enqueueRetryDelay is how long to wait before retrying to queue an
+// internally generated job when the queue is full
+const enqueueRetryDelay = 100 * time.Millisecond
+
+// ErrInvalidWorkflow is returned for workflows that cannot be run
+var ErrInvalidWorkflow = errors.New("invalid workflow")
+
+// Step is a single unit of work within a workflow. It runs through the
+// pool's normal retry loop once every step in DependsOn has completed or
+// been skipped.
+type Step[T any] struct {
+	ID         string
+	DependsOn  []string
+	Payload    T
+	Process    func(context.Context, T) error
+	OnFailure  FailurePolicy
+	Compensate func(context.Context, T) error
+}
+
+// Workflow is a set of steps forming a directed acyclic graph
+type Workflow[T any] struct {
+	ID    string
+	Steps []Step[T]
+}
+
+// WorkflowStatus is a snapshot of a workflow and each of its steps
+type WorkflowStatus struct {
+	ID    string                `json:"id"`
+	State WorkflowState         `json:"state"`
+	Steps map[string]StepStatus `json:"steps"`
+}
+
+// workflowRun tracks the progress of a submitted workflow
+type workflowRun[T any] struct {
+	mu         sync.Mutex
+	pool       *Pool[T]
+	id         string
+	steps      []Step[T]
+	status     map[string]StepStatus
+	completed  []string
+	inFlight   int
+	compensate bool
+	state      WorkflowState
+	finishedAt time.Time
+}
+
+// SubmitWorkflow validates wf and queues every step without dependencies.
+// Later steps are queued as their dependencies finish.
+func (p *Pool[T]) SubmitWorkflow(wf Workflow[T]) error {
+	if err := validateWorkflow(wf); err != nil {
+		return err
+	}
+
+	p.mu.RLock()
+	closed := p.closed
+	p.mu.RUnlock()
+	if closed {
+		return ErrPoolClosed
+	}
+
+	run := &workflowRun[T]{
+		pool:   p,
+		id:     wf.ID,
+		steps:  wf.Steps,
+		status: make(map[string]StepStatus, len(wf.Steps)),
+		state:  WorkflowRunning,
+	}
+	for _, step := range wf.Steps {
+		run.status[step.ID] = StepPending
+	}
+
+	p.workflowsMu.Lock()
+	if _, exists := p.workflows[wf.ID]; exists {
+		p.workflowsMu.Unlock()
+		return fmt.Errorf("workflow %s already exists", wf.ID)
+	}
+	p.workflows[wf.ID] = run
+	p.workflowsMu.Unlock()
+
+	run.mu.Lock()
+	jobs := run.advance()
+	run.mu.Unlock()
+
+	for _, job := range jobs {
+		p.enqueue(job)
+	}
+	return nil
+}
+
+// WorkflowStatus returns the current status of a submitted workflow
+func (p *Pool[T]) WorkflowStatus(id string) (WorkflowStatus, bool) {
+	p.workflowsMu.Lock()
+	run, ok := p.workflows[id]
+	p.workflowsMu.Unlock()
+	if !ok {
+		return WorkflowStatus{}, false
+	}
+
+	run.mu.Lock()
+	defer run.mu.Unlock()
+
+	status := WorkflowStatus{
+		ID:    run.id,
+		State: run.state,
+		Steps: make(map[string]StepStatus, len(run.status)),
+	}
+	for id, stepStatus := range run.status {
+		status.Steps[id] = stepStatus
+	}
+	return status, true
+}
+
+// pruneWorkflows forgets workflows that finished more than workflowRetention ago
+func (p *Pool[T]) pruneWorkflows() {
+	p.workflowsMu.Lock()
+	defer p.workflowsMu.Unlock()
+
+	for id, run := range p.workflows {
+		run.mu.Lock()
+		expired := !run.finishedAt.IsZero() && time.Since(run.finishedAt) > workflowRetention
+		run.mu.Unlock()
+		if expired {
+			delete(p.workflows, id)
+		}
+	}
+}
+
+// enqueue queues a job generated by the pool itself, such as a workflow
+// step. Unlike Submit it never rejects a job because the queue is full but
+// tries again shortly, and it reports the job as aborted once the pool is
+// closed. It is called from worker goroutines, so it must not block on p.mu.
+func (p *Pool[T]) enqueue(job Job[T]) {
+	if p.mu.TryRLock() {
+		closed, sent := p.closed, false
+		if !closed {
+			select {
+			case p.jobs <- job:
+				sent = true
+			default:
+			}
+		}
+		p.mu.RUnlock()
+
+		if sent {
+			return
+		}
+		if closed {
+			job.notify(outcomeAborted)
+			return
+		}
+	}
+	time.AfterFunc(enqueueRetryDelay, func() { p.enqueue(job) })
+}
+
+// advance schedules every step whose dependencies are satisfied and moves
+// the workflow into a final state once nothing is left to run. It returns
+// the jobs to queue. The caller must hold r.mu.
+func (r *workflowRun[T]) advance() []Job[T] {
+	switch r.state {
+	case WorkflowRunning:
+		var jobs []Job[T]
+		for _, step := range r.steps {
+			if r.status[step.ID] == StepPending && r.dependenciesMet(step) {
+				r.status[step.ID] = StepScheduled
+				r.inFlight++
+				jobs = append(jobs, r.stepJob(step))
+			}
+		}
+		if len(jobs) == 0 && r.inFlight == 0 {
+			r.finish(WorkflowCompleted)
+		}
+		return jobs
+	case WorkflowFailed:
+		if r.inFlight > 0 || !r.finishedAt.IsZero() {
+			return nil
+		}
+		if r.compensate {
+			r.state = WorkflowCompensating
+			return r.nextCompensation()
+		}
+		r.finish(WorkflowFailed)
+	}
+	return nil
+}
+
+func (r *workflowRun[T]) dependenciesMet(step Step[T]) bool {
+	for _, dep := range step.DependsOn {
+		if status := r.status[dep]; status != StepCompleted && status != StepSkipped {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *workflowRun[T]) stepJob(step Step[T]) Job[T] {
+	return Job[T]{
+		ID:      r.id + "/" + step.ID,
+		Payload: step.Payload,
+		Process: step.Process,
+		done: func(result outcome) {
+			r.stepDone(step, result)
+		},
+	}
+}
+
+// stepDone records the outcome of a step and queues whatever can run next
+func (r *workflowRun[T]) stepDone(step Step[T], result outcome) {
+	r.mu.Lock()
+	r.inFlight--
+
+	switch {
+	case result == outcomeCompleted:
+		r.status[step.ID] = StepCompleted
+		r.completed = append(r.completed, step.ID)
+	case result == outcomeFailed && step.OnFailure == FailSkip:
+		log.Printf("Workflow %s skipping failed step %s", r.id, step.ID)
+		r.status[step.ID] = StepSkipped
+	case result == outcomeFailed:
+		r.status[step.ID] = StepFailed
+		r.fail(step.OnFailure == FailCompensate)
+	default:
+		// The pool shut down before the step could finish; it did not fail,
+		// so it is not compensated as if it had
+		r.status[step.ID] = StepCancelled
+		r.fail(false)
+	}
+
+	jobs := r.advance()
+	r.mu.Unlock()
+
+	for _, job := range jobs {
+		r.pool.enqueue(job)
+	}
+}
+
+// fail stops the workflow, cancelling steps that have not been scheduled.
+// The caller must hold r.mu.
+func (r *workflowRun[T]) fail(compensate bool) {
+	r.compensate = r.compensate || compensate
+	if r.state != WorkflowRunning {
+		return
+	}
+
+	log.Printf("Workflow %s failed", r.id)
+	r.state = WorkflowFailed
+	for id, status := range r.status {
+		if status == StepPending {
+			r.status[id] = StepCancelled
+		}
+	}
+}
+
+// nextCompensation returns the compensation job for the most recently
+// completed step that has one, or finishes the workflow when none are left.
+// The caller must hold r.mu.
+func (r *workflowRun[T]) nextCompensation() []Job[T] {
+	for len(r.completed) > 0 {
+		last := len(r.completed) - 1
+		id := r.completed[last]
+		r.completed = r.completed[:last]
+
+		for _, step := range r.steps {
+			if step.ID != id || step.Compensate == nil {
+				continue
+			}
+			step := step
+			return []Job[T]{{
+				ID:      r.id + "/" + step.ID + "/compensate",
+				Payload: step.Payload,
+				Process: step.Compensate,
+				done: func(result outcome) {
+					r.compensationDone(step, result)
+				},
+			}}
+		}
+	}
+
+	r.finish(WorkflowCompensated)
+	return nil
+}
+
+// compensationDone records the outcome of a compensation and moves on to the next one
+func (r *workflowRun[T]) compensationDone(step Step[T], result outcome) {
+	r.mu.Lock()
+	if result == outcomeCompleted {
+		r.status[step.ID] = StepCompensated
+	} else {
+		log.Printf("Workflow %s could not compensate step %s", r.id, step.ID)
+	}
+	jobs := r.nextCompensation()
+	r.mu.Unlock()
+
+	for _, job := range jobs {
+		r.pool.enqueue(job)
+	}
+}
+
+// finish moves the workflow into a final state. The caller must hold r.mu.
+func (r *workflowRun[T]) finish(state WorkflowState) {
+	r.state = state
+	r.finishedAt = time.Now()
+	log.Printf("Workflow %s %s", r.id, state)
+}
+
+// validateWorkflow checks step IDs and dependencies and rejects cycles
+func validateWorkflow[T any](wf Workflow[T]) error {
+	if wf.ID == "" {
+		return fmt.Errorf("%w: missing ID", ErrInvalidWorkflow)
+	}
+	if len(wf.Steps) == 0 {
+		return fmt.Errorf("%w: no steps", ErrInvalidWorkflow)
+	}
+
+	indegree := make(map[string]int, len(wf.Steps))
+	for _, step := range wf.Steps {
+		if step.ID == "" {
+			return fmt.Errorf("%w: step with missing ID", ErrInvalidWorkflow)
+		}
+		if step.Process == nil {
+			return fmt.Errorf("%w: step %s has no Process function", ErrInvalidWorkflow, step.ID)
+		}
+		if _, exists := indegree[step.ID]; exists {
+			return fmt.Errorf("%w: duplicate step %s", ErrInvalidWorkflow, step.ID)
+		}
+		indegree[step.ID] = len(step.DependsOn)
+	}
+
+	dependents := make(map[string][]string)
+	for _, step := range wf.Steps {
+		for _, dep := range step.DependsOn {
+			if _, exists := indegree[dep]; !exists {
+				return fmt.Errorf("%w: step %s depends on unknown step %s", ErrInvalidWorkflow, step.ID, dep)
+			}
+			dependents[dep] = append(dependents[dep], step.ID)
+		}
+	}
+
+	// Kahn's algorithm: every step is visited only if the graph is acyclic
+	var queue []string
+	for id, n := range indegree {
+		if n == 0 {
+			queue = append(queue, id)
+		}
+	}
+	visited := 0
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		visited++
+		for _, next := range dependents[id] {
+			indegree[next]--
+			if indegree[next] == 0 {
+				queue = append(queue, next)
+			}
+		}
+	}
+	if visited != len(wf.Steps) {
+		return fmt.Errorf("%w: dependency cycle", ErrInvalidWorkflow)
+	}
+	return nil
+}
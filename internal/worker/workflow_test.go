@@ -0,0 +1,182 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// waitForWorkflow polls until the workflow reaches a final state
+func waitForWorkflow[T any](t *testing.T, pool *Pool[T], id string) WorkflowStatus {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		status, ok := pool.WorkflowStatus(id)
+		if !ok {
+			t.Fatalf("Workflow %s not found", id)
+		}
+		switch status.State {
+		case WorkflowCompleted, WorkflowFailed, WorkflowCompensated:
+			return status
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("Workflow %s did not finish", id)
+	return WorkflowStatus{}
+}
+
+func TestWorkflowRunsStepsInDependencyOrder(t *testing.T) {
+	pool := NewPool[string](testConfig(4, 10, ""))
+
+	var (
+		mu    sync.Mutex
+		order []string
+	)
+	record := func(ctx context.Context, name string) error {
+		mu.Lock()
+		defer mu.Unlock()
+		order = append(order, name)
+		return nil
+	}
+
+	err := pool.SubmitWorkflow(Workflow[string]{
+		ID: "subscribe",
+		Steps: []Step[string]{
+			{ID: "welcome", Payload: "welcome", Process: record, DependsOn: []string{"persist"}},
+			{ID: "verify", Payload: "verify", Process: record},
+			{ID: "persist", Payload: "persist", Process: record, DependsOn: []string{"verify"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected submit error: %v", err)
+	}
+
+	status := waitForWorkflow(t, pool, "subscribe")
+	if status.State != WorkflowCompleted {
+		t.Errorf("Expected completed workflow, got %+v", status)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	want := []string{"verify", "persist", "welcome"}
+	for i := range want {
+		if i >= len(order) || order[i] != want[i] {
+			t.Fatalf("Expected order %v, got %v", want, order)
+		}
+	}
+}
+
+func TestWorkflowFailurePolicies(t *testing.T) {
+	ok := func(ctx context.Context, s string) error { return nil }
+	fail := func(ctx context.Context, s string) error { return errors.New("boom") }
+
+	tests := []struct {
+		name      string
+		policy    FailurePolicy
+		wantState WorkflowState
+		wantSteps map[string]StepStatus
+	}{
+		{
+			name:      "abort",
+			policy:    FailAbort,
+			wantState: WorkflowFailed,
+			wantSteps: map[string]StepStatus{"a": StepCompleted, "b": StepFailed, "c": StepCancelled},
+		},
+		{
+			name:      "skip",
+			policy:    FailSkip,
+			wantState: WorkflowCompleted,
+			wantSteps: map[string]StepStatus{"a": StepCompleted, "b": StepSkipped, "c": StepCompleted},
+		},
+		{
+			name:      "compensate",
+			policy:    FailCompensate,
+			wantState: WorkflowCompensated,
+			wantSteps: map[string]StepStatus{"a": StepCompensated, "b": StepFailed, "c": StepCancelled},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool := NewPool[string](testConfig(2, 10, ""))
+
+			err := pool.SubmitWorkflow(Workflow[string]{
+				ID: tt.name,
+				Steps: []Step[string]{
+					{ID: "a", Process: ok, Compensate: ok},
+					{ID: "b", Process: fail, DependsOn: []string{"a"}, OnFailure: tt.policy},
+					{ID: "c", Process: ok, DependsOn: []string{"b"}},
+				},
+			})
+			if err != nil {
+				t.Fatalf("Unexpected submit error: %v", err)
+			}
+
+			status := waitForWorkflow(t, pool, tt.name)
+			if status.State != tt.wantState {
+				t.Errorf("Expected state %s, got %s", tt.wantState, status.State)
+			}
+			for id, want := range tt.wantSteps {
+				if got := status.Steps[id]; got != want {
+					t.Errorf("Expected step %s to be %s, got %s", id, want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestSubmitWorkflowRejectsCycles(t *testing.T) {
+	pool := NewPool[string](testConfig(1, 10, ""))
+	ok := func(ctx context.Context, s string) error { return nil }
+
+	err := pool.SubmitWorkflow(Workflow[string]{
+		ID: "cycle",
+		Steps: []Step[string]{
+			{ID: "a", Process: ok, DependsOn: []string{"b"}},
+			{ID: "b", Process: ok, DependsOn: []string{"a"}},
+		},
+	})
+	if !errors.Is(err, ErrInvalidWorkflow) {
+		t.Errorf("Expected ErrInvalidWorkflow, got %v", err)
+	}
+}
+
+func TestWorkflowStepInterruptedByShutdown(t *testing.T) {
+	pool := NewPool[string](testConfig(1, 10, ""))
+	ok := func(ctx context.Context, s string) error { return nil }
+	started := make(chan struct{})
+
+	err := pool.SubmitWorkflow(Workflow[string]{
+		ID: "shutdown",
+		Steps: []Step[string]{
+			{ID: "a", Process: ok, Compensate: ok},
+			{ID: "b", DependsOn: []string{"a"}, OnFailure: FailCompensate, Process: func(ctx context.Context, s string) error {
+				close(started)
+				<-ctx.Done()
+				return ctx.Err()
+			}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected submit error: %v", err)
+	}
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	pool.Drain(ctx)
+
+	status := waitForWorkflow(t, pool, "shutdown")
+	if status.State != WorkflowFailed {
+		t.Errorf("Expected state %s, got %s", WorkflowFailed, status.State)
+	}
+	if got := status.Steps["b"]; got != StepCancelled {
+		t.Errorf("Expected the interrupted step to be %s, got %s", StepCancelled, got)
+	}
+	if got := status.Steps["a"]; got != StepCompleted {
+		t.Errorf("Expected step a not to be compensated, got %s", got)
+	}
+}